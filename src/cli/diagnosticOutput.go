@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var diagnosticOutputs = map[string]func(w io.Writer, diagnostics dictionary.Diagnostics) error{
+	"text":   outputDiagnosticsToConsole,
+	"json":   outputDiagnosticsToJson,
+	"sarif":  outputDiagnosticsToSarif,
+	"github": outputDiagnosticsToGithub,
+}
+
+func addDiagnosticFormatFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("format", "text", "Output format of problems (text, json, sarif, github)")
+}
+
+// outputDiagnostics writes diagnostics to stdout in the format given by the 'format' flag.
+func outputDiagnostics(cmd *cobra.Command, diagnostics dictionary.Diagnostics) error {
+	format, _ := cmd.Flags().GetString("format")
+	output, ok := diagnosticOutputs[format]
+	if !ok {
+		return errors.Errorf("unknown output format '%s'", format)
+	}
+	if err := output(os.Stdout, diagnostics); err != nil {
+		return errors.Wrap(err, "failed to write output")
+	}
+	return nil
+}
+
+// diagnosticLocation returns the position, key and language of a diagnostic, such as `content.json: a.b [en]`.
+func diagnosticLocation(diagnostic dictionary.Diagnostic) string {
+	location := diagnostic.Position.String()
+	if diagnostic.Key != "" {
+		location += fmt.Sprintf(": %s", diagnostic.Key)
+	}
+	if diagnostic.Language != "" {
+		location += fmt.Sprintf(" [%s]", diagnostic.Language)
+	}
+	return location
+}
+
+func outputDiagnosticsToConsole(w io.Writer, diagnostics dictionary.Diagnostics) error {
+	severityColors := map[dictionary.Severity]*color.Color{
+		dictionary.SeverityError:   color.New(color.FgRed),
+		dictionary.SeverityWarning: color.New(color.FgYellow),
+		dictionary.SeverityInfo:    color.New(color.FgBlue),
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(
+			w, "%s: %s: %s (%s)\n",
+			diagnosticLocation(diagnostic), severityColors[diagnostic.Severity].Sprint(diagnostic.Severity),
+			diagnostic.Message, diagnostic.Code,
+		)
+	}
+	fmt.Fprintf(
+		w, "%d errors, %d warnings, %d infos\n",
+		diagnostics.Count(dictionary.SeverityError),
+		diagnostics.Count(dictionary.SeverityWarning),
+		diagnostics.Count(dictionary.SeverityInfo),
+	)
+	return nil
+}
+
+type jsonDiagnostic struct {
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	Key         string `json:"key,omitempty"`
+	Language    string `json:"language,omitempty"`
+	TemplateKey string `json:"template_key,omitempty"`
+	File        string `json:"file,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Column      int    `json:"column,omitempty"`
+}
+
+func outputDiagnosticsToJson(w io.Writer, diagnostics dictionary.Diagnostics) error {
+	converted := make([]jsonDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		converted = append(converted, jsonDiagnostic{
+			Code:        string(diagnostic.Code),
+			Severity:    string(diagnostic.Severity),
+			Message:     diagnostic.Message,
+			Key:         string(diagnostic.Key),
+			Language:    diagnostic.Language,
+			TemplateKey: diagnostic.TemplateKey,
+			File:        diagnostic.Position.File,
+			Line:        diagnostic.Position.Line,
+			Column:      diagnostic.Position.Column,
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(converted)
+}
+
+// outputDiagnosticsToSarif writes diagnostics as a SARIF 2.1.0 log.
+func outputDiagnosticsToSarif(w io.Writer, diagnostics dictionary.Diagnostics) error {
+	sarifLevels := map[dictionary.Severity]string{
+		dictionary.SeverityError:   "error",
+		dictionary.SeverityWarning: "warning",
+		dictionary.SeverityInfo:    "note",
+	}
+
+	ruleSet := map[string]struct{}{}
+	results := make([]map[string]interface{}, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		ruleSet[string(diagnostic.Code)] = struct{}{}
+		result := map[string]interface{}{
+			"ruleId":  string(diagnostic.Code),
+			"level":   sarifLevels[diagnostic.Severity],
+			"message": map[string]interface{}{"text": diagnostic.Message},
+		}
+		if diagnostic.Position.File != "" {
+			physicalLocation := map[string]interface{}{
+				"artifactLocation": map[string]interface{}{"uri": diagnostic.Position.File},
+			}
+			if diagnostic.Position.Line > 0 {
+				region := map[string]interface{}{"startLine": diagnostic.Position.Line}
+				if diagnostic.Position.Column > 0 {
+					region["startColumn"] = diagnostic.Position.Column
+				}
+				physicalLocation["region"] = region
+			}
+			location := map[string]interface{}{"physicalLocation": physicalLocation}
+			if diagnostic.Key != "" {
+				location["logicalLocations"] = []map[string]interface{}{
+					{"fullyQualifiedName": string(diagnostic.Key)},
+				}
+			}
+			result["locations"] = []map[string]interface{}{location}
+		}
+		results = append(results, result)
+	}
+
+	ruleIds := make([]string, 0, len(ruleSet))
+	for id := range ruleSet {
+		ruleIds = append(ruleIds, id)
+	}
+	sort.Strings(ruleIds)
+	rules := make([]map[string]interface{}, 0, len(ruleIds))
+	for _, id := range ruleIds {
+		rules = append(rules, map[string]interface{}{"id": id})
+	}
+
+	log := map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]interface{}{
+			{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":           "donggu",
+						"informationUri": "https://github.com/maasasia/donggu",
+						"rules":          rules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(log)
+}
+
+var githubMessageEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+var githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+// outputDiagnosticsToGithub writes diagnostics as workflow commands of GitHub Actions,
+// which annotate the lines of the diagnostics.
+func outputDiagnosticsToGithub(w io.Writer, diagnostics dictionary.Diagnostics) error {
+	githubCommands := map[dictionary.Severity]string{
+		dictionary.SeverityError:   "error",
+		dictionary.SeverityWarning: "warning",
+		dictionary.SeverityInfo:    "notice",
+	}
+	for _, diagnostic := range diagnostics {
+		properties := []string{}
+		if diagnostic.Position.File != "" {
+			properties = append(properties, "file="+githubPropertyEscaper.Replace(diagnostic.Position.File))
+			if diagnostic.Position.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", diagnostic.Position.Line))
+			}
+			if diagnostic.Position.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", diagnostic.Position.Column))
+			}
+		}
+		properties = append(properties, "title="+githubPropertyEscaper.Replace(string(diagnostic.Code)))
+
+		message := diagnostic.Message
+		if diagnostic.Key != "" {
+			location := string(diagnostic.Key)
+			if diagnostic.Language != "" {
+				location += fmt.Sprintf(" [%s]", diagnostic.Language)
+			}
+			message = location + ": " + message
+		}
+		fmt.Fprintf(
+			w, "::%s %s::%s\n",
+			githubCommands[diagnostic.Severity], strings.Join(properties, ","), githubMessageEscaper.Replace(message),
+		)
+	}
+	return nil
+}
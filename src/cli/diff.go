@@ -14,14 +14,45 @@ diff compares the difference from a content file to the current project's conten
 The source is the content file and the destination is the content.json file.
 For instance, if a key 'screens.login' exists in the content file
 but is deleted in content.json, 'screens.login' would be marked as deleted.
-This behavior can be reversed by the '--reverse' flag.`
+This behavior can be reversed by the '--reverse' flag.
+
+Changed texts are shown with the words deleted and inserted between the source and the destination.
+Without colors, such as in the file given by '-o', they are marked as [-deleted-] and {+inserted+}.
+
+'--output' sets the format of the difference, written to stdout or the file given by '-o':
+  console   a table of the changed texts (default)
+  csv       the old and new texts of each language
+  json      the difference with changes of template keys, for other programs
+  markdown  a summary and a table of the changes, to be posted as a comment of a pull request
+  unified   a unified diff of the changed texts, with a hunk for each key
+
+The JSON output has the following schema, with entries sorted by key and fields by language:
+
+  {
+    "version": 1,
+    "source": "content.json", "destination": "task.json",
+    "summary": {"source_keys": 0, "destination_keys": 0, "unchanged_keys": 0,
+                "created_keys": 0, "deleted_keys": 0, "changed_keys": 0},
+    "entries": [{
+      "key": "screens.title", "difference": "created | deleted | changed",
+      "fields": [{
+        "language": "en", "change": "create | delete | change",
+        "old": "text or null", "new": "text or null",
+        "template_changes": [{"key": "NAME", "old_type": "int", "new_type": "plural"}]
+      }]
+    }]
+  }`
 
 func execDiffCommand(cmd *cobra.Command, args []string) error {
 	reverse, _ := cmd.Flags().GetBool("reverse")
 	otherFileFormat, filePath := args[0], args[1]
-	content, meta, err := loadProjectFromCommand(cmd)
+	projectRoot, err := getProjectRoot(cmd)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, err := loadProject(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
 	}
 
 	filePath, err = filepath.Abs(filePath)
@@ -35,36 +66,56 @@ func execDiffCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	var diff dictionary.ContentDifference
+	labels := diffLabels{
+		Source:      projectFilePath(projectRoot, resolveProjectFiles(projectRoot).contentPath(meta)),
+		Destination: args[1],
+	}
 	if reverse {
 		diff = dictionary.DiffContents(otherFile, content)
+		labels.Source, labels.Destination = labels.Destination, labels.Source
 	} else {
 		diff = dictionary.DiffContents(content, otherFile)
 	}
 
-	var writeErr error
+	outputName, _ := cmd.Flags().GetString("output")
+	outputPath, _ := cmd.Flags().GetString("output-file")
 	if useCsv, _ := cmd.Flags().GetBool("csv"); useCsv {
-		writeErr = outputDiffToCsv(diff)
-	} else {
-		outputDiffToConsole(diff, reverse)
-		writeErr = nil
+		outputName = "csv"
+		if outputPath == "" {
+			outputPath = "diff.csv"
+		}
+	}
+	output, ok := diffOutputs[outputName]
+	if !ok {
+		return errors.Errorf("unknown output format '%s'", outputName)
+	}
+
+	w, closeOutput, err := openReportOutput(outputPath)
+	if err != nil {
+		return err
 	}
-	if writeErr != nil {
-		return errors.Wrap(err, "failed to write output")
+	defer closeOutput()
+	if writeErr := output(w, diff, labels); writeErr != nil {
+		return errors.Wrap(writeErr, "failed to write output")
 	}
 	return nil
 }
 
 func initDiffCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "diff [--console | --csv] [--reverse] format file",
+		Use:   "diff [--output console|csv|json|markdown|unified] [-o file] [--reverse] format file",
 		Short: "Show differences of a content file against the current project",
 		Long:  diffCommandDescription,
 		Args:  cobra.ExactArgs(2),
-		Run:   wrapExecCommand(execDiffCommand),
+		Run:   wrapReportCommand(execDiffCommand),
 	}
 
+	cmd.PersistentFlags().String("output", "console", "Output format of the difference (console, csv, json, markdown, unified)")
+	cmd.PersistentFlags().StringP("output-file", "o", "", "File to write the difference to (default: stdout)")
 	cmd.PersistentFlags().Bool("console", false, "Print the difference to the console")
-	cmd.PersistentFlags().Bool("csv", false, "Print the difference to a CSV file")
+	cmd.PersistentFlags().Bool("csv", false, "Write the difference to diff.csv, same as '--output csv -o diff.csv'")
+	cmd.PersistentFlags().MarkDeprecated("console", "it is the default output")
+	cmd.PersistentFlags().MarkDeprecated("csv", "use '--output csv -o diff.csv' instead")
 	cmd.PersistentFlags().Bool("reverse", false, "Reverse the source/destination between files. See --help for details.")
 
 	return cmd
@@ -2,109 +2,333 @@ package cli
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/maasasia/donggu/dictionary"
-	"github.com/pkg/errors"
 	"github.com/rodaine/table"
 )
 
-func outputDiffToConsole(diff dictionary.ContentDifference, reverse bool) {
-	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
-	createdColorFmt := color.New(color.FgBlue).SprintFunc()
-	deletedColorFmt := color.New(color.FgRed).SprintfFunc()
-	changedColorFmt := color.New(color.FgYellow).SprintFunc()
+// diffLabels are the names of the source and destination of a difference.
+type diffLabels struct {
+	Source      string
+	Destination string
+}
 
-	tbl := table.New("Key", "Difference").WithHeaderFormatter(headerFmt)
-	diffMap := map[string]string{}
+var diffOutputs = map[string]func(w io.Writer, diff dictionary.ContentDifference, labels diffLabels) error{
+	"console":  outputDiffToConsole,
+	"csv":      outputDiffToCsv,
+	"json":     outputDiffToJson,
+	"markdown": outputDiffToMarkdown,
+	"unified":  outputDiffToUnified,
+}
 
-	if reverse {
-		fmt.Println("Changes from given file to project file (content.json)")
-	} else {
-		fmt.Println("Changes from project file (content.json) to given file")
-	}
+// diffRow is a row of the difference of a field.
+type diffRow struct {
+	Key        string
+	Difference string
+	Lang       string
+	Field      dictionary.FieldDifference
+}
 
-	fmt.Printf("- Number of keys in source: %d\n", diff.SourceKeyCount)
-	fmt.Printf("- Number of keys in destination: %d\n", diff.DestKeyCount)
-	fmt.Printf("- Number of unchanged keys: %d\n", diff.UnchangedKeyCount)
+// diffRows returns the differences of all fields, sorted by key and language.
+func diffRows(diff dictionary.ContentDifference) []diffRow {
+	rows := []diffRow{}
+	addEntry := func(key dictionary.EntryKey, difference string, entry dictionary.Entry, flag dictionary.DiffFlag) {
+		for _, lang := range sortedLanguages(entry) {
+			field := dictionary.FieldDifference{Flag: flag}
+			if flag == dictionary.CreateFlag {
+				field.New = entry[lang]
+			} else {
+				field.Old = entry[lang]
+			}
+			rows = append(rows, diffRow{Key: string(key), Difference: difference, Lang: lang, Field: field})
+		}
+	}
 
 	for _, key := range diff.CreatedKeys {
-		diffMap[string(key)] = createdColorFmt("Created")
+		addEntry(key, "Created", diff.CreatedEntries[key], dictionary.CreateFlag)
 	}
 	for _, key := range diff.DeletedKeys {
-		diffMap[string(key)] = deletedColorFmt("Deleted")
+		addEntry(key, "Deleted", diff.DeletedEntries[key], dictionary.DeleteFlag)
 	}
-
 	for key, value := range diff.Changes {
-		changes := []string{}
+		langs := make([]string, 0, len(value))
+		for lang := range value {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			rows = append(rows, diffRow{Key: string(key), Difference: "Changed", Lang: lang, Field: value[lang]})
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Key != rows[j].Key {
+			return rows[i].Key < rows[j].Key
+		}
+		return rows[i].Lang < rows[j].Lang
+	})
+	return rows
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	langs := make([]string, 0, len(entry))
+	for lang := range entry {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// inlineDiff returns the word-level difference of a field, with deleted and inserted words colored.
+// Without colors, they are marked in the style of `git diff --word-diff` ([-deleted-]{+inserted+}).
+func inlineDiff(field dictionary.FieldDifference) string {
+	deletedFmt := color.New(color.FgRed, color.CrossedOut).SprintFunc()
+	insertedFmt := color.New(color.FgGreen).SprintFunc()
+	escape := func(text string) string {
+		return strings.ReplaceAll(text, "\n", "\\n")
+	}
 
-		for lang, change := range value {
-			changes = append(changes, fmt.Sprintf("%s: %s", lang, change))
+	builder := strings.Builder{}
+	for _, segment := range dictionary.DiffTexts(field.Old, field.New) {
+		text := escape(segment.Text)
+		switch {
+		case segment.Op == dictionary.TextDiffDelete && color.NoColor:
+			builder.WriteString("[-" + text + "-]")
+		case segment.Op == dictionary.TextDiffDelete:
+			builder.WriteString(deletedFmt(text))
+		case segment.Op == dictionary.TextDiffInsert && color.NoColor:
+			builder.WriteString("{+" + text + "+}")
+		case segment.Op == dictionary.TextDiffInsert:
+			builder.WriteString(insertedFmt(text))
+		default:
+			builder.WriteString(text)
 		}
-		sort.Strings(changes)
-		diffMap[string(key)] = fmt.Sprintf(
-			"%s(%s)",
-			changedColorFmt("Changed"),
-			strings.Join(changes, ", "),
-		)
 	}
+	return builder.String()
+}
 
-	changeKeys := make([]string, 0, len(diffMap))
-	for key := range diffMap {
-		changeKeys = append(changeKeys, key)
+func outputDiffToConsole(w io.Writer, diff dictionary.ContentDifference, labels diffLabels) error {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	differenceFmts := map[string]func(a ...interface{}) string{
+		"Created": color.New(color.FgBlue).SprintFunc(),
+		"Deleted": color.New(color.FgRed).SprintFunc(),
+		"Changed": color.New(color.FgYellow).SprintFunc(),
 	}
-	sort.Strings(changeKeys)
 
-	for _, key := range changeKeys {
-		tbl.AddRow(key, diffMap[key])
+	tbl := table.New("Key", "Difference", "Language", "Value").WithHeaderFormatter(headerFmt).WithWriter(w)
+
+	fmt.Fprintf(w, "Changes from %s to %s\n", labels.Source, labels.Destination)
+	fmt.Fprintf(w, "- Number of keys in source: %d\n", diff.SourceKeyCount)
+	fmt.Fprintf(w, "- Number of keys in destination: %d\n", diff.DestKeyCount)
+	fmt.Fprintf(w, "- Number of unchanged keys: %d\n", diff.UnchangedKeyCount)
+
+	for _, row := range diffRows(diff) {
+		tbl.AddRow(row.Key, differenceFmts[row.Difference](row.Difference), row.Lang, inlineDiff(row.Field))
 	}
 	tbl.Print()
+	return nil
 }
 
-func outputDiffToCsv(diff dictionary.ContentDifference) error {
-	diffMap := map[string][]string{}
+func outputDiffToCsv(w io.Writer, diff dictionary.ContentDifference, _ diffLabels) error {
+	csvWriter := csv.NewWriter(w)
+	csvWriter.Write([]string{"Key", "Difference", "Language", "Change", "Old", "New"})
 
-	for _, key := range diff.CreatedKeys {
-		diffMap[string(key)] = []string{"Created", ""}
+	for _, row := range diffRows(diff) {
+		csvWriter.Write([]string{row.Key, row.Difference, row.Lang, string(row.Field.Flag), row.Field.Old, row.Field.New})
 	}
-	for _, key := range diff.DeletedKeys {
-		diffMap[string(key)] = []string{"Deleted", ""}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// jsonDiffVersion is the version of the schema of the JSON output, increased on incompatible changes.
+const jsonDiffVersion = 1
+
+type jsonDiff struct {
+	Version     int             `json:"version"`
+	Source      string          `json:"source"`
+	Destination string          `json:"destination"`
+	Summary     jsonDiffSummary `json:"summary"`
+	Entries     []jsonDiffEntry `json:"entries"`
+}
+
+type jsonDiffSummary struct {
+	SourceKeys      int `json:"source_keys"`
+	DestinationKeys int `json:"destination_keys"`
+	UnchangedKeys   int `json:"unchanged_keys"`
+	CreatedKeys     int `json:"created_keys"`
+	DeletedKeys     int `json:"deleted_keys"`
+	ChangedKeys     int `json:"changed_keys"`
+}
+
+type jsonDiffEntry struct {
+	Key        string          `json:"key"`
+	Difference string          `json:"difference"`
+	Fields     []jsonDiffField `json:"fields"`
+}
+
+type jsonDiffField struct {
+	Language        string                      `json:"language"`
+	Change          string                      `json:"change"`
+	Old             *string                     `json:"old"`
+	New             *string                     `json:"new"`
+	TemplateChanges []jsonDiffTemplateKeyChange `json:"template_changes"`
+}
+
+type jsonDiffTemplateKeyChange struct {
+	Key     string `json:"key"`
+	OldType string `json:"old_type,omitempty"`
+	NewType string `json:"new_type,omitempty"`
+}
+
+// outputDiffToJson writes the difference as JSON. Entries are sorted by key and fields by language.
+// The old text of created fields and the new text of deleted fields are null.
+func outputDiffToJson(w io.Writer, diff dictionary.ContentDifference, labels diffLabels) error {
+	converted := jsonDiff{
+		Version:     jsonDiffVersion,
+		Source:      labels.Source,
+		Destination: labels.Destination,
+		Summary: jsonDiffSummary{
+			SourceKeys:      diff.SourceKeyCount,
+			DestinationKeys: diff.DestKeyCount,
+			UnchangedKeys:   diff.UnchangedKeyCount,
+			CreatedKeys:     len(diff.CreatedKeys),
+			DeletedKeys:     len(diff.DeletedKeys),
+			ChangedKeys:     len(diff.Changes),
+		},
+		Entries: []jsonDiffEntry{},
 	}
-	for key, value := range diff.Changes {
-		changes := []string{}
 
-		for lang, change := range value {
-			changes = append(changes, fmt.Sprintf("%s: %s", lang, change))
+	for _, row := range diffRows(diff) {
+		last := len(converted.Entries) - 1
+		if last < 0 || converted.Entries[last].Key != row.Key {
+			converted.Entries = append(converted.Entries, jsonDiffEntry{
+				Key:        row.Key,
+				Difference: strings.ToLower(row.Difference),
+				Fields:     []jsonDiffField{},
+			})
+			last++
 		}
-		sort.Strings(changes)
-		diffMap[string(key)] = []string{
-			"Changed",
-			strings.Join(changes, ", "),
+
+		field := jsonDiffField{
+			Language:        row.Lang,
+			Change:          strings.ToLower(string(row.Field.Flag)),
+			TemplateChanges: []jsonDiffTemplateKeyChange{},
+		}
+		if oldText := row.Field.Old; row.Field.Flag != dictionary.CreateFlag {
+			field.Old = &oldText
+		}
+		if newText := row.Field.New; row.Field.Flag != dictionary.DeleteFlag {
+			field.New = &newText
+		}
+		for _, change := range row.Field.TemplateChanges() {
+			field.TemplateChanges = append(field.TemplateChanges, jsonDiffTemplateKeyChange{
+				Key:     change.Key,
+				OldType: string(change.OldKind),
+				NewType: string(change.NewKind),
+			})
 		}
+		converted.Entries[last].Fields = append(converted.Entries[last].Fields, field)
 	}
 
-	changeKeys := make([]string, 0, len(diffMap))
-	for key := range diffMap {
-		changeKeys = append(changeKeys, key)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(converted)
+}
+
+// outputDiffToMarkdown writes the difference as Markdown, to be posted as a comment of a pull request.
+// Changed texts are shown with <del> and <ins>, and changes of template keys are listed separately,
+// as they change the signatures of generated code.
+func outputDiffToMarkdown(w io.Writer, diff dictionary.ContentDifference, labels diffLabels) error {
+	rows := diffRows(diff)
+	fmt.Fprintf(w, "### Content changes\n\n")
+	fmt.Fprintf(w, "Changes from `%s` to `%s`: ", labels.Source, labels.Destination)
+	fmt.Fprintf(
+		w, "**%d** created, **%d** deleted, **%d** changed, %d unchanged keys\n",
+		len(diff.CreatedKeys), len(diff.DeletedKeys), len(diff.Changes), diff.UnchangedKeyCount,
+	)
+	if len(rows) == 0 {
+		return nil
 	}
-	sort.Strings(changeKeys)
 
-	file, err := os.OpenFile("diff.csv", os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
-	if err != nil {
-		return errors.Wrap(err, "failed to open csv file")
+	fmt.Fprintf(w, "\n| Key | Difference | Language | Value |\n| --- | --- | --- | --- |\n")
+	templateChanges := []string{}
+	for _, row := range rows {
+		value := strings.Builder{}
+		for _, segment := range dictionary.DiffTexts(row.Field.Old, row.Field.New) {
+			text := markdownTableText(segment.Text)
+			switch segment.Op {
+			case dictionary.TextDiffDelete:
+				value.WriteString("<del>" + text + "</del>")
+			case dictionary.TextDiffInsert:
+				value.WriteString("<ins>" + text + "</ins>")
+			default:
+				value.WriteString(text)
+			}
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", row.Key, row.Difference, row.Lang, value.String())
+
+		for _, change := range row.Field.TemplateChanges() {
+			templateChanges = append(templateChanges, fmt.Sprintf("- `%s` [%s]: %s", row.Key, row.Lang, templateKeyChangeText(change)))
+		}
+	}
+
+	if len(templateChanges) > 0 {
+		fmt.Fprintf(w, "\n#### Template changes\n\n%s\n", strings.Join(templateChanges, "\n"))
 	}
-	defer file.Close()
-	csvWriter := csv.NewWriter(file)
-	defer csvWriter.Flush()
-	csvWriter.Write([]string{"Key", "Difference", "Details"})
+	return nil
+}
 
-	for _, key := range changeKeys {
-		csvWriter.Write([]string{key, diffMap[key][0], diffMap[key][1]})
+func templateKeyChangeText(change dictionary.TemplateKeyChange) string {
+	switch {
+	case change.OldKind == "":
+		return fmt.Sprintf("added `%s` (%s)", change.Key, change.NewKind)
+	case change.NewKind == "":
+		return fmt.Sprintf("removed `%s` (%s)", change.Key, change.OldKind)
+	default:
+		return fmt.Sprintf("changed `%s` from %s to %s", change.Key, change.OldKind, change.NewKind)
 	}
+}
+
+// markdownTableText escapes a text to be written in a cell of a Markdown table.
+func markdownTableText(text string) string {
+	return strings.NewReplacer(
+		"&", "&amp;", "<", "&lt;", ">", "&gt;", "|", "\\|", "`", "\\`", "*", "\\*", "_", "\\_", "\n", "<br>",
+	).Replace(text)
+}
 
+// outputDiffToUnified writes the difference in the style of a unified diff, with a hunk for each entry
+// and a line for each language. Lines of multiline texts are indented under the language.
+func outputDiffToUnified(w io.Writer, diff dictionary.ContentDifference, labels diffLabels) error {
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", labels.Source, labels.Destination)
+	writeText := func(prefix, lang, text string) {
+		for index, line := range strings.Split(text, "\n") {
+			if index == 0 {
+				fmt.Fprintf(w, "%s%s: %s\n", prefix, lang, line)
+			} else {
+				fmt.Fprintf(w, "%s%s  %s\n", prefix, strings.Repeat(" ", len(lang)), line)
+			}
+		}
+	}
+
+	lastKey := ""
+	for _, row := range diffRows(diff) {
+		if row.Key != lastKey {
+			fmt.Fprintf(w, "@@ %s @@\n", row.Key)
+			lastKey = row.Key
+		}
+		if row.Field.Flag != dictionary.CreateFlag {
+			writeText("-", row.Lang, row.Field.Old)
+		}
+		if row.Field.Flag != dictionary.DeleteFlag {
+			writeText("+", row.Lang, row.Field.New)
+		}
+	}
 	return nil
 }
@@ -5,13 +5,24 @@ import (
 	"path/filepath"
 
 	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/pseudo"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+const exportCommandDescription = `
+export writes the project in a format, such as library code or files for translators.
+
+'--pseudo' adds a pseudo-localized language of the source language to the exported project,
+as a supported language with the plural rules of the source language. Letters are replaced with
+accented letters, texts are made longer by '--pseudo-expansion' percent and wrapped with brackets,
+so that hardcoded and truncated texts can be found before translations are available.
+Templates are left untouched. The source language is 'lint.source_language' of the metadata
+or the first required language, unless '--pseudo-source' is given.`
+
 func execExportCommand(cmd *cobra.Command, args []string) error {
 	exporterName, targetRoot := args[0], args[1]
-	content, meta, err := loadProjectFromCommand(cmd)
+	content, meta, positions, err := loadProjectFromCommand(cmd)
 	if err != nil {
 		return err
 	}
@@ -20,11 +31,15 @@ func execExportCommand(cmd *cobra.Command, args []string) error {
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "metadata file has errors")
 	}
-	validateErr = content.Validate(meta, dictionary.ContentValidationOptions{})
+	validateErr = validateContent(cmd, content, meta, positions)
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "content file has errors")
 	}
 
+	if content, meta, err = addPseudoLanguage(cmd, content, meta); err != nil {
+		return err
+	}
+
 	targetRoot, err = filepath.Abs(targetRoot)
 	if err != nil {
 		return errors.Wrapf(err, "invalid target path '%s'", targetRoot)
@@ -44,6 +59,16 @@ func execExportCommand(cmd *cobra.Command, args []string) error {
 			return errors.Wrap(err, "invalid options")
 		}
 
+		// File exporters that can also write a whole project are used as such for directories.
+		if directoryExporter := loadFileDirectoryExporter(exporterName); directoryExporter != nil {
+			if isDirectory, _ := isPathDirectory(targetRoot); isDirectory {
+				if err := directoryExporter.Export(targetRoot, content, meta, exporterOptions); err != nil {
+					return errors.Wrap(err, "failed to export project")
+				}
+				return nil
+			}
+		}
+
 		file, err := os.OpenFile(targetRoot, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
 		if err != nil {
 			return errors.Wrap(err, "failed to open target file")
@@ -60,13 +85,49 @@ func execExportCommand(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// addPseudoLanguage adds the pseudo-localized language given by the 'pseudo' flag to the content and metadata,
+// so that exporters handle it as a supported language.
+func addPseudoLanguage(
+	cmd *cobra.Command, content dictionary.ContentRepresentation, meta dictionary.Metadata,
+) (dictionary.ContentRepresentation, dictionary.Metadata, error) {
+	lang, _ := cmd.Flags().GetString("pseudo")
+	if lang == "" {
+		return content, meta, nil
+	}
+	source, _ := cmd.Flags().GetString("pseudo-source")
+	if source == "" {
+		source = meta.LintSourceLanguage()
+	}
+	expansion, _ := cmd.Flags().GetInt("pseudo-expansion")
+	if expansion < 0 {
+		return nil, meta, errors.New("pseudo expansion should not be negative")
+	}
+	options := pseudo.DefaultOptions()
+	options.Expansion = float64(expansion) / 100
+	options.Accents, _ = cmd.Flags().GetBool("pseudo-accents")
+	options.Brackets, _ = cmd.Flags().GetBool("pseudo-brackets")
+
+	content, meta, err := pseudo.AddLanguage(content, meta, lang, source, options)
+	if err != nil {
+		return nil, meta, errors.Wrap(err, "failed to add pseudo language")
+	}
+	return content, meta, nil
+}
+
 func initExportCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "export format path",
+		Use:   "export [--pseudo lang] format path",
 		Short: "Export something",
+		Long:  exportCommandDescription,
 		Args:  cobra.ExactArgs(2),
 		Run:   wrapExecCommand(execExportCommand),
 	}
 
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().String("pseudo", "", "Pseudo-localized language to add, such as en-XA")
+	cmd.PersistentFlags().String("pseudo-source", "", "Language to pseudo-localize (default: source language)")
+	cmd.PersistentFlags().Int("pseudo-expansion", 40, "Percentage of the length added to pseudo-localized texts")
+	cmd.PersistentFlags().Bool("pseudo-accents", true, "Replace letters with accented letters in pseudo-localized texts")
+	cmd.PersistentFlags().Bool("pseudo-brackets", true, "Wrap pseudo-localized texts with brackets")
 	return cmd
 }
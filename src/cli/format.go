@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"github.com/maasasia/donggu/dictionary"
 	"github.com/maasasia/donggu/exporter"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,7 +11,7 @@ func execFormatCommand(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to resolve project root")
 	}
-	content, meta, err := loadProject(projectRoot)
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
 	if err != nil {
 		return errors.Wrap(err, "failed to load project")
 	}
@@ -21,12 +20,12 @@ func execFormatCommand(cmd *cobra.Command, _ []string) error {
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "metadata file has errors")
 	}
-	validateErr = content.Validate(meta, dictionary.ContentValidationOptions{})
+	validateErr = validateContent(cmd, content, meta, positions)
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "content file has errors")
 	}
 
-	exportErr := exporter.JsonDictionaryExporter{}.Export(projectRoot, content, meta, exporter.OptionMap{})
+	exportErr := projectExporter(projectRoot).Export(projectRoot, content, meta, exporter.OptionMap{})
 	if exportErr != nil {
 		return errors.Wrap(err, "failed to save file")
 	}
@@ -40,5 +39,6 @@ func initFormatCommand() *cobra.Command {
 		Short:   "Format content and metadata file",
 		Run:     wrapExecCommand(execFormatCommand),
 	}
+	addStrictTemplatesFlag(cmd)
 	return cmd
 }
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/lint"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const lintCommandDescription = `
+lint checks content.json for issues which are not structural errors,
+such as placeholders or punctuations differing between languages.
+
+Languages are compared against the source language, which is 'lint.source_language'
+of the metadata or the first required language.
+Rules are configured under 'lint.rules' of the metadata, keyed by the rule name:
+
+  "lint": {
+    "rules": {
+      "untranslated": {"severity": "off"},
+      "max-length": {"severity": "warning", "max": 80, "languages": {"de": 100}}
+    }
+  }
+
+The severity of a rule is one of 'error', 'warning', 'info' and 'off'.
+The command fails if any issue with the severity 'error' is found.
+
+Rules:
+  placeholder-mismatch  placeholders differ from the source language (error)
+  whitespace-mismatch   leading or trailing whitespace differ from the source language (warning)
+  punctuation-mismatch  the punctuation at the end differs from the source language (warning)
+  untranslated          the text is identical to the source language (warning)
+  missing-context       the entry has placeholders but no context (warning)
+  max-length            the text is longer than 'max' or the limit in 'languages' (error)`
+
+func execLintCommand(cmd *cobra.Command, _ []string) error {
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
+	}
+	if validateErr := meta.Validate(); validateErr != nil {
+		return errors.Wrap(validateErr, "metadata file has errors")
+	}
+
+	linter, err := lint.NewLinter(meta, lint.DefaultRules())
+	if err != nil {
+		return errors.Wrap(err, "invalid lint configuration")
+	}
+	diagnostics := linter.Lint(projectFilePath(projectRoot, resolveProjectFiles(projectRoot).contentPath(meta)), content).WithPositions(positions)
+	if err := outputDiagnostics(cmd, diagnostics); err != nil {
+		return err
+	}
+	if errorCount := diagnostics.Count(dictionary.SeverityError); errorCount > 0 {
+		return errors.Errorf("%d errors found", errorCount)
+	}
+	return nil
+}
+
+func initLintCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "lint [--format text|json|sarif|github]",
+		Short: "Check content for issues between languages",
+		Long:  lintCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapReportCommand(execLintCommand),
+	}
+	addDiagnosticFormatFlag(cmd)
+	return cmd
+}
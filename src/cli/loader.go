@@ -6,24 +6,47 @@ import (
 )
 
 var fileImporters = map[string]importer.DictionaryFileImporter{
-	"json": importer.JsonDictionaryImporter{},
-	"csv":  importer.CsvDictionaryImporter{},
+	"json":  importer.JsonDictionaryImporter{},
+	"yaml":  importer.YamlDictionaryImporter{},
+	"toml":  importer.TomlDictionaryImporter{},
+	"csv":   importer.CsvDictionaryImporter{},
+	"icu":   importer.IcuDictionaryImporter{},
+	"po":    importer.PoDictionaryImporter{},
+	"xliff": importer.XliffDictionaryImporter{},
 }
 
 var importers = map[string]importer.DictionaryImporter{
-	"json": importer.JsonDictionaryImporter{},
-	"csv":  importer.CsvDictionaryImporter{},
+	"json":  importer.JsonDictionaryImporter{},
+	"yaml":  importer.YamlDictionaryImporter{},
+	"toml":  importer.TomlDictionaryImporter{},
+	"csv":   importer.CsvDictionaryImporter{},
+	"po":    importer.PoDictionaryImporter{},
+	"xliff": importer.XliffDictionaryImporter{},
 }
 
 var fileExporters = map[string]exporter.DictionaryFileExporter{
-	"json": exporter.JsonDictionaryExporter{},
-	"csv":  exporter.CsvDictionaryExporter{},
+	"json":       exporter.JsonDictionaryExporter{},
+	"yaml":       exporter.YamlDictionaryExporter{},
+	"toml":       exporter.TomlDictionaryExporter{},
+	"csv":        exporter.CsvDictionaryExporter{},
+	"icu":        exporter.IcuDictionaryExporter{},
+	"po":         exporter.PoDictionaryExporter{},
+	"xliff":      exporter.XliffDictionaryExporter{},
+	"i18next":    exporter.I18nextDictionaryExporter{},
+	"vue-i18n":   exporter.VueI18nDictionaryExporter{},
+	"react-intl": exporter.ReactIntlDictionaryExporter{},
 }
 
 var projectExporters = map[string]exporter.DictionaryProjectExporter{
 	"typescript": exporter.TypescriptDictionaryExporter{},
 	"ts-react":   exporter.TypescriptReactDictionaryExporter{},
 	"golang":     exporter.GolangDictionaryExporter{},
+	"kotlin":     exporter.KotlinDictionaryExporter{},
+	"swift":      exporter.SwiftDictionaryExporter{},
+	"python":     exporter.PythonDictionaryExporter{},
+	"rust":       exporter.RustDictionaryExporter{},
+	"android":    exporter.AndroidDictionaryExporter{},
+	"ios":        exporter.IosDictionaryExporter{},
 }
 
 func loadImporter(name string) importer.DictionaryImporter {
@@ -51,6 +74,15 @@ func loadFileExporter(name string) exporter.DictionaryFileExporter {
 	return nil
 }
 
+// loadFileDirectoryExporter returns the file exporter as a DictionaryProjectExporter,
+// if it supports exporting to a directory.
+func loadFileDirectoryExporter(name string) exporter.DictionaryProjectExporter {
+	if directoryExporter, ok := fileExporters[name].(exporter.DictionaryProjectExporter); ok {
+		return directoryExporter
+	}
+	return nil
+}
+
 func loadProjectExporter(name string) exporter.DictionaryProjectExporter {
 	projectExporter, isProjectExporter := projectExporters[name]
 
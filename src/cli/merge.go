@@ -9,17 +9,58 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const mergeCommandDescription = `
+merge merges a content file to the current project.
+
+Without '--base', the fields of the content file overwrite the fields of the project,
+and '--strategy', '--conflicts' and '--markers' cannot be given.
+
+With '--base', the changes made to the project (ours) and to the content file (theirs) since
+the base are merged. The base is the content both sides started from, such as a file exported
+earlier for translators, and is read in the same format as the content file.
+A field changed differently on both sides, or an entry deleted on one side and changed
+on the other, is a conflict. Conflicts are resolved by '--strategy':
+  ours         keep the values of the project
+  theirs       take the values of the content file
+  fail         leave conflicts unresolved (default)
+  interactive  ask for each conflict
+
+If conflicts are left unresolved, the project is not changed and the command fails.
+'--conflicts' writes them to a CSV file, and '--markers' writes the project with the values
+of all sides in the conflicting texts, separated by conflict markers.
+Texts with conflict markers are reported by validate until they are resolved.`
+
+var mergeStrategies = map[string]struct{}{
+	"ours":        {},
+	"theirs":      {},
+	"fail":        {},
+	"interactive": {},
+}
+
 func execMergeCommand(cmd *cobra.Command, args []string) error {
 	format, filePath := args[0], args[1]
+	strategy, _ := cmd.Flags().GetString("strategy")
+	if _, ok := mergeStrategies[strategy]; !ok {
+		return errors.Errorf("unknown merge strategy '%s'", strategy)
+	}
+	basePath, _ := cmd.Flags().GetString("base")
+	if basePath == "" {
+		// Conflicts are only found with a base, so these flags would be ignored.
+		for _, flag := range []string{"strategy", "conflicts", "markers"} {
+			if cmd.Flags().Changed(flag) {
+				return errors.Errorf("'--%s' requires '--base'", flag)
+			}
+		}
+	}
 	projectRoot, err := getProjectRoot(cmd)
 	if err != nil {
 		return errors.Wrap(err, "failed to resolve project root")
 	}
-	content, meta, err := loadProject(projectRoot)
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
 	if err != nil {
 		return errors.Wrap(err, "failed to load project")
 	}
-	validateErr := content.Validate(meta, dictionary.ContentValidationOptions{})
+	validateErr := validateContent(cmd, content, meta, positions)
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "merge destination content file has errors")
 	}
@@ -33,26 +74,84 @@ func execMergeCommand(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed merging file")
 	}
-	validateErr = mergeContent.Validate(meta, dictionary.ContentValidationOptions{})
+	validateErr = mergeContent.Validate(meta, contentValidationOptions(cmd))
 	if validateErr != nil {
 		return errors.Wrap(validateErr, "merge source content file has errors")
 	}
 
-	content = dictionary.MergeContent(mergeContent, content)
+	markedConflicts := 0
+	if basePath == "" {
+		content = dictionary.MergeContent(mergeContent, content)
+	} else {
+		basePath, err = filepath.Abs(basePath)
+		if err != nil {
+			return errors.Wrapf(err, "invalid base path '%s'", basePath)
+		}
+		baseContent, err := readContentFile(meta, format, basePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read base file")
+		}
+
+		result := dictionary.ThreeWayMerge(baseContent, content, mergeContent)
+		if markedConflicts, err = resolveMergeConflicts(cmd, result, strategy); err != nil {
+			return err
+		}
+		content = result.Content
+	}
 
-	exportErr := exporter.JsonDictionaryExporter{}.Export(projectRoot, content, meta, exporter.OptionMap{})
+	exportErr := projectExporter(projectRoot).Export(projectRoot, content, meta, exporter.OptionMap{})
 	if exportErr != nil {
-		return errors.Wrap(err, "failed to save merged file")
+		return errors.Wrap(exportErr, "failed to save merged file")
+	}
+	if markedConflicts > 0 {
+		return errors.Errorf("%d conflicts are marked in the content file", markedConflicts)
 	}
 	return nil
 }
 
+// resolveMergeConflicts resolves the conflicts of a merge with a strategy, and returns the number of conflicts
+// marked in the content. It fails if conflicts are left unresolved without '--markers'.
+func resolveMergeConflicts(cmd *cobra.Command, result *dictionary.MergeResult, strategy string) (int, error) {
+	switch strategy {
+	case "ours":
+		result.ResolveAll(dictionary.MergeOurs)
+	case "theirs":
+		result.ResolveAll(dictionary.MergeTheirs)
+	case "interactive":
+		if err := resolveMergeConflictsInteractively(result); err != nil {
+			return 0, err
+		}
+	}
+
+	unresolved := result.Unresolved()
+	if len(unresolved) == 0 {
+		return 0, nil
+	}
+	if conflictsPath, _ := cmd.Flags().GetString("conflicts"); conflictsPath != "" {
+		if err := outputMergeConflictsToCsv(conflictsPath, result.Conflicts, unresolved); err != nil {
+			return 0, errors.Wrap(err, "failed to write conflicts")
+		}
+	}
+	if markers, _ := cmd.Flags().GetBool("markers"); markers {
+		result.MarkConflicts()
+		return len(unresolved), nil
+	}
+	outputMergeConflictsToConsole(result.Conflicts, unresolved)
+	return 0, errors.Errorf("%d conflicts found", len(unresolved))
+}
+
 func initMergeCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "merge format file",
+		Use:   "merge [--base file] [--strategy ours|theirs|fail|interactive] format file",
 		Short: "Merge a content file to the current project",
+		Long:  mergeCommandDescription,
 		Args:  cobra.ExactArgs(2),
 		Run:   wrapExecCommand(execMergeCommand),
 	}
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().String("base", "", "Content file both sides started from, for a three-way merge")
+	cmd.PersistentFlags().String("strategy", "fail", "How to resolve conflicts (ours, theirs, fail, interactive)")
+	cmd.PersistentFlags().String("conflicts", "", "CSV file to write unresolved conflicts to")
+	cmd.PersistentFlags().Bool("markers", false, "Write unresolved conflicts to the content file with conflict markers")
 	return cmd
 }
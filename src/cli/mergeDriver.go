@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const mergeDriverCommandDescription = `
+merge-driver merges content files as a git merge driver.
+
+Instead of merging the lines of the files, the fields of entries are merged by key and language
+in the same way as 'merge --base', and the result is written to the current file in the order of fmt.
+Files written in the nested form stay nested.
+
+Register the driver in the git config and assign it to content files in .gitattributes:
+  git config merge.donggu.name "donggu content merge driver"
+  git config merge.donggu.driver "donggu merge-driver %O %A %B %P"
+  echo "content.json merge=donggu" >> .gitattributes
+
+The format is detected from the extension of the path (json, yaml, yml, toml), or set with '--format'.
+Only single content files are supported, and not the files of content directories split by language.
+
+Conflicts are resolved by '--strategy':
+  ours    keep the values of the current branch
+  theirs  take the values of the other branch
+  fail    write the values of all sides in the conflicting texts with conflict markers,
+          and leave the file conflicted (default)`
+
+var mergeDriverFormats = map[string]string{
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+	".toml": "toml",
+}
+
+var mergeDriverStrategies = map[string]struct{}{
+	"ours":   {},
+	"theirs": {},
+	"fail":   {},
+}
+
+func execMergeDriverCommand(cmd *cobra.Command, args []string) error {
+	basePath, currentPath, otherPath := args[0], args[1], args[2]
+	strategy, _ := cmd.Flags().GetString("strategy")
+	if _, ok := mergeDriverStrategies[strategy]; !ok {
+		return errors.Errorf("unknown merge strategy '%s'", strategy)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = "json"
+		if len(args) > 3 {
+			if extFormat, ok := mergeDriverFormats[strings.ToLower(filepath.Ext(args[3]))]; ok {
+				format = extFormat
+			}
+		}
+	}
+	rewriter, ok := loadFileExporter(format).(exporter.ContentFileRewriter)
+	if !ok {
+		return errors.Errorf("format '%s' is not supported by the merge driver", format)
+	}
+
+	contents := make([]dictionary.ContentRepresentation, 3)
+	for index, filePath := range []string{basePath, currentPath, otherPath} {
+		content, err := readContentFile(dictionary.Metadata{}, format, filePath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read '%s'", filePath)
+		}
+		contents[index] = content
+	}
+
+	result := dictionary.ThreeWayMerge(contents[0], contents[1], contents[2])
+	switch strategy {
+	case "ours":
+		result.ResolveAll(dictionary.MergeOurs)
+	case "theirs":
+		result.ResolveAll(dictionary.MergeTheirs)
+	}
+	unresolved := result.Unresolved()
+	if len(unresolved) > 0 {
+		outputMergeConflictsToConsole(result.Conflicts, unresolved)
+		result.MarkConflicts()
+	}
+
+	if err := rewriter.RewriteContentFile(currentPath, result.Content); err != nil {
+		return errors.Wrap(err, "failed to write merged file")
+	}
+	if len(unresolved) > 0 {
+		return errors.Errorf("%d conflicts are marked in the merged file", len(unresolved))
+	}
+	return nil
+}
+
+func initMergeDriverCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "merge-driver [--format format] [--strategy ours|theirs|fail] base current other [path]",
+		Short: "Merge content files as a git merge driver",
+		Long:  mergeDriverCommandDescription,
+		Args:  cobra.RangeArgs(3, 4),
+		Run:   wrapExecCommand(execMergeDriverCommand),
+	}
+	cmd.PersistentFlags().String("format", "", "Format of the content files (default: detected from path)")
+	cmd.PersistentFlags().String("strategy", "fail", "How to resolve conflicts (ours, theirs, fail)")
+	return cmd
+}
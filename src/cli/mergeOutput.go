@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/rodaine/table"
+)
+
+// mergeConflictField returns the field of a conflict, or a description for conflicts of whole entries.
+func mergeConflictField(conflict dictionary.MergeConflict) string {
+	if conflict.Field == "" {
+		return "(entry)"
+	}
+	return conflict.Field
+}
+
+// mergeConflictValue returns the value of a side of a conflict for display.
+func mergeConflictValue(conflict dictionary.MergeConflict, side dictionary.MergeSide) string {
+	value := conflict.Value(side)
+	switch {
+	case !value.Exists:
+		return "(deleted)"
+	case conflict.Field == "" && side == dictionary.MergeBase:
+		return "(exists)"
+	case conflict.Field == "":
+		return "(changed)"
+	default:
+		return value.Text
+	}
+}
+
+func outputMergeConflictsToConsole(conflicts []dictionary.MergeConflict, indices []int) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := table.New("Key", "Field", "Ours", "Theirs").WithHeaderFormatter(headerFmt)
+	for _, index := range indices {
+		conflict := conflicts[index]
+		tbl.AddRow(
+			conflict.Key,
+			mergeConflictField(conflict),
+			mergeConflictValue(conflict, dictionary.MergeOurs),
+			mergeConflictValue(conflict, dictionary.MergeTheirs),
+		)
+	}
+	tbl.Print()
+}
+
+func outputMergeConflictsToCsv(filePath string, conflicts []dictionary.MergeConflict, indices []int) error {
+	file, err := os.OpenFile(filePath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return errors.Wrap(err, "failed to open csv file")
+	}
+	defer file.Close()
+
+	csvWriter := csv.NewWriter(file)
+	csvWriter.Write([]string{"Key", "Field", "Base", "Ours", "Theirs"})
+	for _, index := range indices {
+		conflict := conflicts[index]
+		csvWriter.Write([]string{
+			string(conflict.Key),
+			mergeConflictField(conflict),
+			mergeConflictValue(conflict, dictionary.MergeBase),
+			mergeConflictValue(conflict, dictionary.MergeOurs),
+			mergeConflictValue(conflict, dictionary.MergeTheirs),
+		})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// resolveMergeConflictsInteractively asks how to resolve each conflict. Conflicts can also be left unresolved.
+func resolveMergeConflictsInteractively(result *dictionary.MergeResult) error {
+	keyFmt := color.New(color.Bold).SprintfFunc()
+	sideFmt := color.New(color.FgYellow).SprintfFunc()
+	choices := []string{"ours", "theirs", "base", "leave unresolved"}
+
+	unresolved := result.Unresolved()
+	for count, index := range unresolved {
+		conflict := result.Conflicts[index]
+		fmt.Printf("\n[%d/%d] %s %s\n", count+1, len(unresolved), keyFmt("%s", conflict.Key), mergeConflictField(conflict))
+		for _, side := range []dictionary.MergeSide{dictionary.MergeBase, dictionary.MergeOurs, dictionary.MergeTheirs} {
+			fmt.Printf("%s\n%s\n", sideFmt("%s:", side), mergeConflictValue(conflict, side))
+		}
+
+		prompt := promptui.Select{Label: "Resolve with", Items: choices}
+		choice, _, err := prompt.Run()
+		if err != nil {
+			return errors.Wrap(err, "prompt failed")
+		}
+		switch choices[choice] {
+		case "ours":
+			result.Resolve(index, dictionary.MergeOurs)
+		case "theirs":
+			result.Resolve(index, dictionary.MergeTheirs)
+		case "base":
+			result.Resolve(index, dictionary.MergeBase)
+		}
+	}
+	return nil
+}
@@ -16,9 +16,16 @@ func init() {
 	rootCmd.PersistentFlags().StringP("project", "P", "", "Project folder (default: current directory)")
 	rootCmd.AddCommand(initExportCommand())
 	rootCmd.AddCommand(initMergeCommand())
+	rootCmd.AddCommand(initMergeDriverCommand())
 	rootCmd.AddCommand(initFormatCommand())
 	rootCmd.AddCommand(initDiffCommand())
 	rootCmd.AddCommand(initInitCommand())
+	rootCmd.AddCommand(initLintCommand())
+	rootCmd.AddCommand(initStatsCommand())
+	rootCmd.AddCommand(initStatusCommand())
+	rootCmd.AddCommand(initSuggestCommand())
+	rootCmd.AddCommand(initTranslateCommand())
+	rootCmd.AddCommand(initValidateCommand())
 }
 
 func Execute() {
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-multierror"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+const statsCommandDescription = `
+stats reports the translation coverage of each supported language: the number of keys,
+the number of keys with a text of the language, and the number of words and characters of the texts.
+The coverage is also reported for each top-level subtree of the keys, such as 'screens' of 'screens.title'.
+
+Words and characters are counted without templates. Characters of Chinese, Japanese and Thai
+are counted as words, as they are not separated by spaces.
+
+'--output' sets the format of the report, written to stdout or the file given by '-o':
+  console  tables of the languages and the subtrees (default)
+  json     the report for other programs, with the schema below
+
+  {
+    "version": 1,
+    "missing_optional_translations": 12,
+    "languages": [{"language": "ja", "required": false, "total_keys": 120, "translated_keys": 108,
+                   "missing_keys": 12, "coverage": 90, "words": 1024, "characters": 3072}],
+    "subtrees": [{"name": "screens", "languages": [...]}]
+  }
+
+  The name of the subtree of the keys without a parent is empty.
+
+'--min-coverage' fails the command if the coverage of a language is lower than the percentage,
+such as '--min-coverage ja=95,ko=100'. The report is written before the command fails.`
+
+// statsOutputs are the formats of the report of the stats command.
+var statsOutputs = map[string]func(w io.Writer, stats dictionary.ContentStats) error{
+	"console": outputStatsToConsole,
+	"json":    outputStatsToJson,
+}
+
+func execStatsCommand(cmd *cobra.Command, _ []string) error {
+	outputName, _ := cmd.Flags().GetString("output")
+	outputPath, _ := cmd.Flags().GetString("output-file")
+	output, ok := statsOutputs[outputName]
+	if !ok {
+		return errors.Errorf("unknown output format '%s'", outputName)
+	}
+	minCoverageFlags, _ := cmd.Flags().GetStringSlice("min-coverage")
+	minCoverages, err := parseMinCoverages(minCoverageFlags)
+	if err != nil {
+		return errors.Wrap(err, "invalid '--min-coverage'")
+	}
+
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
+	}
+	if validateErr := meta.Validate(); validateErr != nil {
+		return errors.Wrap(validateErr, "metadata file has errors")
+	}
+	if validateErr := validateContent(cmd, content, meta, positions); validateErr != nil {
+		return errors.Wrap(validateErr, "content file has errors")
+	}
+
+	stats := dictionary.ComputeContentStats(content, meta)
+	languages := make([]string, 0, len(minCoverages))
+	for lang := range minCoverages {
+		if _, ok := stats.Language(lang); !ok {
+			return errors.Errorf("invalid '--min-coverage': language '%s' is not in supported languages", lang)
+		}
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+
+	w, closeOutput, err := openReportOutput(outputPath)
+	if err != nil {
+		return err
+	}
+	defer closeOutput()
+	if writeErr := output(w, stats); writeErr != nil {
+		return errors.Wrap(writeErr, "failed to write output")
+	}
+
+	var coverageErr *multierror.Error
+	for _, lang := range languages {
+		langStats, _ := stats.Language(lang)
+		if langStats.Coverage() < minCoverages[lang] {
+			coverageErr = multierror.Append(coverageErr, errors.Errorf(
+				"coverage of '%s' is %s, lower than %s", lang, formatCoverage(langStats.Coverage()), formatCoverage(minCoverages[lang]),
+			))
+		}
+	}
+	return coverageErr.ErrorOrNil()
+}
+
+// parseMinCoverages parses the minimum coverages of languages given as `lang=percentage`.
+func parseMinCoverages(items []string) (map[string]float64, error) {
+	minCoverages := map[string]float64{}
+	for _, item := range items {
+		lang, percentage, found := strings.Cut(item, "=")
+		lang = strings.TrimSpace(lang)
+		if !found || lang == "" {
+			return nil, errors.Errorf("expected 'language=percentage', got '%s'", item)
+		}
+		coverage, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(percentage), "%"), 64)
+		if err != nil || coverage < 0 || coverage > 100 {
+			return nil, errors.Errorf("coverage of '%s' should be a percentage between 0 and 100, got '%s'", lang, percentage)
+		}
+		minCoverages[lang] = coverage
+	}
+	return minCoverages, nil
+}
+
+func formatCoverage(coverage float64) string {
+	return strconv.FormatFloat(coverage, 'f', 1, 64) + "%"
+}
+
+func outputStatsToConsole(w io.Writer, stats dictionary.ContentStats) error {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	requiredFmt := color.New(color.FgBlue).SprintFunc()
+
+	fmt.Fprintf(w, "Translation coverage of %d languages\n", len(stats.Languages))
+	fmt.Fprintf(w, "- Number of missing optional translations: %d\n", stats.MissingOptionalTranslations())
+	tbl := table.New("Language", "Required", "Translated", "Missing", "Coverage", "Words", "Characters").
+		WithHeaderFormatter(headerFmt).WithWriter(w)
+	for _, langStats := range stats.Languages {
+		required := ""
+		if langStats.Required {
+			required = requiredFmt("required")
+		}
+		tbl.AddRow(
+			langStats.Language, required, fmt.Sprintf("%d/%d", langStats.TranslatedKeys, langStats.TotalKeys),
+			langStats.MissingKeys(), formatCoverage(langStats.Coverage()), langStats.Words, langStats.Characters,
+		)
+	}
+	tbl.Print()
+
+	fmt.Fprintln(w)
+	subtreeTbl := table.New("Subtree", "Language", "Translated", "Missing", "Coverage", "Words", "Characters").
+		WithHeaderFormatter(headerFmt).WithWriter(w)
+	for _, subtree := range stats.Subtrees {
+		name := subtree.Name
+		if name == "" {
+			name = "(top level)"
+		}
+		for _, langStats := range subtree.Languages {
+			subtreeTbl.AddRow(
+				name, langStats.Language, fmt.Sprintf("%d/%d", langStats.TranslatedKeys, langStats.TotalKeys),
+				langStats.MissingKeys(), formatCoverage(langStats.Coverage()), langStats.Words, langStats.Characters,
+			)
+		}
+	}
+	subtreeTbl.Print()
+	return nil
+}
+
+// jsonStatsVersion is the version of the schema of the JSON output, increased on incompatible changes.
+const jsonStatsVersion = 1
+
+type jsonStats struct {
+	Version                     int                 `json:"version"`
+	MissingOptionalTranslations int                 `json:"missing_optional_translations"`
+	Languages                   []jsonLanguageStats `json:"languages"`
+	Subtrees                    []jsonSubtreeStats  `json:"subtrees"`
+}
+
+type jsonSubtreeStats struct {
+	Name      string              `json:"name"`
+	Languages []jsonLanguageStats `json:"languages"`
+}
+
+type jsonLanguageStats struct {
+	Language       string  `json:"language"`
+	Required       bool    `json:"required"`
+	TotalKeys      int     `json:"total_keys"`
+	TranslatedKeys int     `json:"translated_keys"`
+	MissingKeys    int     `json:"missing_keys"`
+	Coverage       float64 `json:"coverage"`
+	Words          int     `json:"words"`
+	Characters     int     `json:"characters"`
+}
+
+func outputStatsToJson(w io.Writer, stats dictionary.ContentStats) error {
+	languages := func(items []dictionary.LanguageStats) []jsonLanguageStats {
+		converted := make([]jsonLanguageStats, 0, len(items))
+		for _, langStats := range items {
+			converted = append(converted, jsonLanguageStats{
+				Language:       langStats.Language,
+				Required:       langStats.Required,
+				TotalKeys:      langStats.TotalKeys,
+				TranslatedKeys: langStats.TranslatedKeys,
+				MissingKeys:    langStats.MissingKeys(),
+				Coverage:       langStats.Coverage(),
+				Words:          langStats.Words,
+				Characters:     langStats.Characters,
+			})
+		}
+		return converted
+	}
+
+	output := jsonStats{
+		Version:                     jsonStatsVersion,
+		MissingOptionalTranslations: stats.MissingOptionalTranslations(),
+		Languages:                   languages(stats.Languages),
+		Subtrees:                    make([]jsonSubtreeStats, 0, len(stats.Subtrees)),
+	}
+	for _, subtree := range stats.Subtrees {
+		output.Subtrees = append(output.Subtrees, jsonSubtreeStats{Name: subtree.Name, Languages: languages(subtree.Languages)})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func initStatsCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "stats [--output console|json] [-o file] [--min-coverage lang=percentage,...]",
+		Short: "Report the translation coverage per language",
+		Long:  statsCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapReportCommand(execStatsCommand),
+	}
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().String("output", "console", "Output format of the report (console, json)")
+	cmd.PersistentFlags().StringP("output-file", "o", "", "File to write the report to (default: stdout)")
+	cmd.PersistentFlags().StringSlice("min-coverage", nil, "Fail if the coverage of a language is lower, such as 'ja=95'")
+	return cmd
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+const statusCommandDescription = `
+status reports the completion of the translations of each language.
+
+Texts of each language are counted by their state in the 'translation_state' field of the entry,
+such as 'ko:reviewed|alice|2022-07-01T09:00:00Z'. The states are, in the order of the workflow:
+  draft               written but not finished by translators
+  machine-translated  translated by a machine translation provider, such as by 'donggu translate'
+  reviewed            checked by a reviewer
+  approved            approved to be released
+
+A text is done if its state is '--done-state' or later in the workflow. Texts without a state are
+also done, so that projects which do not track states are complete when every text exists.
+'--pending' lists the keys of the texts which are missing or not done.`
+
+// languageStatus is the number of texts of a language, by their translation states.
+type languageStatus struct {
+	Language string
+	Total    int
+	Missing  int
+	Unmarked int
+	States   map[dictionary.TranslationState]int
+	Done     int
+	Pending  []dictionary.EntryKey
+}
+
+func execStatusCommand(cmd *cobra.Command, _ []string) error {
+	doneStateName, _ := cmd.Flags().GetString("done-state")
+	doneState := dictionary.TranslationState(doneStateName)
+	if !doneState.Valid() {
+		return errors.Errorf("unknown translation state '%s'", doneState)
+	}
+	showPending, _ := cmd.Flags().GetBool("pending")
+
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
+	}
+	if validateErr := meta.Validate(); validateErr != nil {
+		return errors.Wrap(validateErr, "metadata file has errors")
+	}
+	if validateErr := validateContent(cmd, content, meta, positions); validateErr != nil {
+		return errors.Wrap(validateErr, "content file has errors")
+	}
+
+	languages, _ := cmd.Flags().GetStringSlice("languages")
+	if len(languages) == 0 {
+		languages = meta.SupportedLanguages
+	}
+	supportedLangSet := meta.SupportedLanguageSet()
+	for _, lang := range languages {
+		if _, ok := supportedLangSet[lang]; !ok {
+			return errors.Errorf("language '%s' is not in supported languages", lang)
+		}
+	}
+
+	statuses, err := translationStatusOf(content, languages, doneState)
+	if err != nil {
+		return err
+	}
+	outputStatusToConsole(statuses)
+	if showPending {
+		outputPendingKeys(statuses)
+	}
+	return nil
+}
+
+// translationStatusOf counts the texts of the languages in the content.
+func translationStatusOf(
+	content dictionary.ContentRepresentation,
+	languages []string,
+	doneState dictionary.TranslationState,
+) ([]languageStatus, error) {
+	flattened := content.ToFlattened()
+	keys := make([]dictionary.EntryKey, 0, len(*flattened))
+	for key := range *flattened {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	statuses := make([]languageStatus, len(languages))
+	for index, lang := range languages {
+		statuses[index] = languageStatus{Language: lang, Total: len(keys), States: map[dictionary.TranslationState]int{}}
+	}
+	for _, key := range keys {
+		entry := (*flattened)[key]
+		entryStatuses, err := entry.TranslationStatuses()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry '%s'", key)
+		}
+		for index, lang := range languages {
+			status := &statuses[index]
+			if _, ok := entry[lang]; !ok {
+				status.Missing++
+				status.Pending = append(status.Pending, key)
+				continue
+			}
+			langStatus, ok := entryStatuses[lang]
+			if !ok {
+				status.Unmarked++
+				status.Done++
+				continue
+			}
+			status.States[langStatus.State]++
+			if translationStateOrder(langStatus.State) >= translationStateOrder(doneState) {
+				status.Done++
+			} else {
+				status.Pending = append(status.Pending, key)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+func translationStateOrder(state dictionary.TranslationState) int {
+	for index, workflowState := range dictionary.TranslationStates {
+		if workflowState == state {
+			return index
+		}
+	}
+	return -1
+}
+
+func outputStatusToConsole(statuses []languageStatus) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	percentage := func(count, total int) string {
+		if total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", float64(count)*100/float64(total))
+	}
+
+	columns := []interface{}{"Language", "Total", "Missing", "No state"}
+	for _, state := range dictionary.TranslationStates {
+		columns = append(columns, string(state))
+	}
+	columns = append(columns, "Done")
+	tbl := table.New(columns...).WithHeaderFormatter(headerFmt)
+	for _, status := range statuses {
+		row := []interface{}{status.Language, status.Total, status.Missing, status.Unmarked}
+		for _, state := range dictionary.TranslationStates {
+			row = append(row, status.States[state])
+		}
+		row = append(row, fmt.Sprintf("%d (%s)", status.Done, percentage(status.Done, status.Total)))
+		tbl.AddRow(row...)
+	}
+	tbl.Print()
+}
+
+func outputPendingKeys(statuses []languageStatus) {
+	for _, status := range statuses {
+		if len(status.Pending) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s: %d pending\n", status.Language, len(status.Pending))
+		for _, key := range status.Pending {
+			fmt.Printf("  %s\n", key)
+		}
+	}
+}
+
+func initStatusCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "status [--languages lang,...] [--done-state state] [--pending]",
+		Short: "Report the completion of translations per language",
+		Long:  statusCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapExecCommand(execStatusCommand),
+	}
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().StringSlice("languages", nil, "Languages to report (default: supported languages)")
+	cmd.PersistentFlags().String("done-state", string(dictionary.ReviewedState), "Earliest translation state of done texts")
+	cmd.PersistentFlags().Bool("pending", false, "List the keys of missing texts and texts which are not done")
+	return cmd
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter"
+	"github.com/maasasia/donggu/suggest"
+	"github.com/pkg/errors"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+const suggestCommandDescription = `
+suggest proposes translations for texts which are missing, or still equal to the text of the source language.
+
+Entries whose source texts are similar to the source text of the entry are looked up in the project,
+and their translations are proposed with the similarity of the source texts. The similarity is
+the normalised edit distance between the texts, where templates are single characters regardless
+of their keys and formats. Keys of the templates in the proposed translations are renamed to
+the keys of the entry in the order they appear in the source texts, and entries with a different
+number of templates, or with templates of kinds incompatible with the renamed templates of the entry,
+such as '#{COUNT|int}' for '#{NAME}', are not proposed.
+
+The source language is 'lint.source_language' of the metadata or the first required language.
+
+'--apply' writes the best proposal of each text to the project, if its similarity is at least '--apply-score'.
+Applied texts are marked as 'draft' by 'donggu suggest' in the 'translation_state' field of the entry.
+The project is not saved if the applied texts fail validation.`
+
+func execSuggestCommand(cmd *cobra.Command, _ []string) error {
+	minScore, _ := cmd.Flags().GetFloat64("min-score")
+	applyScore, _ := cmd.Flags().GetFloat64("apply-score")
+	limit, _ := cmd.Flags().GetInt("limit")
+	apply, _ := cmd.Flags().GetBool("apply")
+	if minScore < 0 || minScore > 1 || applyScore < 0 || applyScore > 1 {
+		return errors.New("scores should be between 0 and 1")
+	}
+
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
+	}
+	if validateErr := meta.Validate(); validateErr != nil {
+		return errors.Wrap(validateErr, "metadata file has errors")
+	}
+	if validateErr := validateContent(cmd, content, meta, positions); validateErr != nil {
+		return errors.Wrap(validateErr, "content file has errors")
+	}
+
+	memory := suggest.NewTranslationMemory(content, meta.LintSourceLanguage())
+	suggestions := memory.Suggest(meta.SupportedLanguages, suggest.Options{MinScore: minScore, Limit: limit})
+	outputSuggestionsToConsole(suggestions)
+
+	if !apply {
+		return nil
+	}
+	applied := content.ToNewFlattened()
+	status := dictionary.TranslationStatus{
+		State:     dictionary.DraftState,
+		Author:    "donggu suggest",
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	// Suggestions of a text are sorted by descending score, so only the first one of each text is applied.
+	appliedFields := map[string]struct{}{}
+	for _, suggestion := range suggestions {
+		field := fmt.Sprintf("%s [%s]", suggestion.Key, suggestion.Language)
+		if _, ok := appliedFields[field]; ok || suggestion.Score < applyScore {
+			continue
+		}
+		appliedFields[field] = struct{}{}
+		entry := copyContentEntry((*applied)[suggestion.Key])
+		entry[suggestion.Language] = suggestion.Text
+		if err := entry.SetTranslationStatus(suggestion.Language, status); err != nil {
+			return errors.Wrapf(err, "failed to set translation state of '%s'", suggestion.Key)
+		}
+		(*applied)[suggestion.Key] = entry
+	}
+
+	// Suggestions are checked against the templates of the entries, but the project is validated again
+	// so that invalid content is never saved.
+	if validateErr := validateContent(cmd, applied, meta, nil); validateErr != nil {
+		return errors.Wrap(validateErr, "applied suggestions have errors, so the project is not saved")
+	}
+	exportErr := projectExporter(projectRoot).Export(projectRoot, applied, meta, exporter.OptionMap{})
+	if exportErr != nil {
+		return errors.Wrap(exportErr, "failed to save file")
+	}
+	fmt.Printf("Applied %d suggestions\n", len(appliedFields))
+	return nil
+}
+
+func outputSuggestionsToConsole(suggestions []suggest.Suggestion) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	missingFmt := color.New(color.FgRed).SprintFunc()
+	escape := func(text string) string {
+		return strings.ReplaceAll(text, "\n", "\\n")
+	}
+
+	tbl := table.New("Key", "Language", "Current", "Score", "Match", "Suggestion").WithHeaderFormatter(headerFmt)
+	for _, suggestion := range suggestions {
+		current := escape(suggestion.Current)
+		if suggestion.Missing {
+			current = missingFmt("(missing)")
+		}
+		tbl.AddRow(
+			suggestion.Key, suggestion.Language, current, fmt.Sprintf("%.0f%%", suggestion.Score*100),
+			suggestion.MatchKey, escape(suggestion.Text),
+		)
+	}
+	tbl.Print()
+}
+
+func initSuggestCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "suggest [--min-score score] [--limit count] [--apply [--apply-score score]]",
+		Short: "Suggest translations from similar texts",
+		Long:  suggestCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapExecCommand(execSuggestCommand),
+	}
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().Float64("min-score", 0.7, "Lowest similarity of suggestions, between 0 and 1")
+	cmd.PersistentFlags().Int("limit", 3, "Number of suggestions for each text (0: unlimited)")
+	cmd.PersistentFlags().Bool("apply", false, "Write the best suggestions to the project")
+	cmd.PersistentFlags().Float64("apply-score", 0.9, "Lowest similarity of suggestions written by '--apply'")
+	return cmd
+}
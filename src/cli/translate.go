@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter"
+	"github.com/maasasia/donggu/translate"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const translateCommandDescription = `
+translate fills missing texts with machine translations of the source language.
+
+The source language is 'lint.source_language' of the metadata or the first required language.
+Texts are translated to the languages given by '--languages', or to the optional languages
+(supported but not required) by default. Templates are sent to the provider as placeholder tokens
+such as <x id="0"/>, and translations which do not keep every token are rejected.
+The choices of plural templates, and of bool templates with their own values, are translated separately.
+Plural choices are rebuilt for the plural categories of the target language, from the choice of
+the same category in the source language or the choice of 'other'.
+Translated texts are marked as 'machine-translated' by 'donggu translate' in the 'translation_state'
+field of the entry, as they need to be reviewed.
+
+Providers:
+  http    POST {"source": "en", "target": "ko", "text": "..."} as JSON to the endpoint, which should
+          respond with {"text": "..."}. The endpoint is given by '--endpoint' or DONGGU_TRANSLATE_ENDPOINT,
+          and DONGGU_TRANSLATE_TOKEN is sent as a bearer token if it is set. (default)
+  pseudo  prefix texts with the language such as "[ko] Hello", for testing
+
+If a text fails to be translated, the texts translated before it are saved and the command fails.`
+
+var translators = map[string]func(cmd *cobra.Command) (translate.Translator, error){
+	"http": func(cmd *cobra.Command) (translate.Translator, error) {
+		endpoint, _ := cmd.Flags().GetString("endpoint")
+		if endpoint == "" {
+			endpoint = os.Getenv("DONGGU_TRANSLATE_ENDPOINT")
+		}
+		if endpoint == "" {
+			return nil, errors.New("endpoint is not set")
+		}
+		return translate.NewHttpTranslator(endpoint, os.Getenv("DONGGU_TRANSLATE_TOKEN")), nil
+	},
+	"pseudo": func(_ *cobra.Command) (translate.Translator, error) {
+		return translate.PseudoTranslator{}, nil
+	},
+}
+
+func execTranslateCommand(cmd *cobra.Command, _ []string) error {
+	providerName, _ := cmd.Flags().GetString("provider")
+	newTranslator, ok := translators[providerName]
+	if !ok {
+		return errors.Errorf("unknown translation provider '%s'", providerName)
+	}
+	translator, err := newTranslator(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "failed to configure provider '%s'", providerName)
+	}
+
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to load project")
+	}
+	if validateErr := meta.Validate(); validateErr != nil {
+		return errors.Wrap(validateErr, "metadata file has errors")
+	}
+	if validateErr := validateContent(cmd, content, meta, positions); validateErr != nil {
+		return errors.Wrap(validateErr, "content file has errors")
+	}
+
+	sourceLanguage := meta.LintSourceLanguage()
+	languages, _ := cmd.Flags().GetStringSlice("languages")
+	if len(languages) == 0 {
+		languages = optionalLanguages(meta)
+	}
+	supportedLangSet := meta.SupportedLanguageSet()
+	for _, lang := range languages {
+		if _, ok := supportedLangSet[lang]; !ok || lang == sourceLanguage {
+			return errors.Errorf("cannot translate to '%s': it should be a supported language other than '%s'", lang, sourceLanguage)
+		}
+	}
+
+	translated := content.ToNewFlattened()
+	keys := make([]dictionary.EntryKey, 0, len(*translated))
+	for key := range *translated {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	status := dictionary.TranslationStatus{
+		State:     dictionary.MachineTranslatedState,
+		Author:    "donggu translate",
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	count := 0
+	var translateErr error
+	for _, key := range keys {
+		entry := (*translated)[key]
+		source, ok := entry[sourceLanguage]
+		if !ok {
+			continue
+		}
+		for _, lang := range languages {
+			if _, exists := entry[lang]; exists {
+				continue
+			}
+			text, err := translate.TranslateText(translator, meta, sourceLanguage, lang, source)
+			if err != nil {
+				translateErr = errors.Wrapf(err, "failed to translate '%s' to '%s'", key, lang)
+				break
+			}
+			entry = copyContentEntry(entry)
+			entry[lang] = text
+			if err := entry.SetTranslationStatus(lang, status); err != nil {
+				return errors.Wrapf(err, "failed to set translation state of '%s'", key)
+			}
+			(*translated)[key] = entry
+			count++
+		}
+		if translateErr != nil {
+			break
+		}
+	}
+
+	if count > 0 {
+		exportErr := projectExporter(projectRoot).Export(projectRoot, translated, meta, exporter.OptionMap{})
+		if exportErr != nil {
+			return errors.Wrap(exportErr, "failed to save file")
+		}
+	}
+	fmt.Printf("Translated %d texts\n", count)
+	return translateErr
+}
+
+// optionalLanguages returns the supported languages which are not required.
+func optionalLanguages(meta dictionary.Metadata) []string {
+	requiredLangSet := meta.RequiredLanguageSet()
+	languages := []string{}
+	for _, lang := range meta.SupportedLanguages {
+		if _, ok := requiredLangSet[lang]; !ok {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
+
+// copyContentEntry returns a copy of an entry, so that entries shared with other contents are not changed.
+func copyContentEntry(entry dictionary.Entry) dictionary.Entry {
+	copied := make(dictionary.Entry, len(entry))
+	for field, text := range entry {
+		copied[field] = text
+	}
+	return copied
+}
+
+func initTranslateCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "translate [--provider http|pseudo] [--endpoint url] [--languages lang,...]",
+		Short: "Fill missing texts with machine translations",
+		Long:  translateCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapExecCommand(execTranslateCommand),
+	}
+	addStrictTemplatesFlag(cmd)
+	cmd.PersistentFlags().String("provider", "http", "Machine translation provider (http, pseudo)")
+	cmd.PersistentFlags().String("endpoint", "", "Endpoint of the http provider (default: DONGGU_TRANSLATE_ENDPOINT)")
+	cmd.PersistentFlags().StringSlice("languages", nil, "Languages to translate to (default: optional languages)")
+	return cmd
+}
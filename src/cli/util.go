@@ -8,32 +8,66 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-multierror"
 	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter"
 	"github.com/maasasia/donggu/importer"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 func wrapExecCommand(exec func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) {
+	return wrapExecCommandTo(os.Stdout, exec)
+}
+
+// wrapReportCommand is same as wrapExecCommand, but writes the result of the command to stderr,
+// so that reports written to stdout can be read by other programs.
+func wrapReportCommand(exec func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) {
+	return wrapExecCommandTo(os.Stderr, exec)
+}
+
+func wrapExecCommandTo(w io.Writer, exec func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
 		startTime := time.Now()
 		if err := exec(cmd, args); err != nil {
-			fmt.Printf("🚫 Failed to run command.\n%s\n", strings.TrimSpace(err.Error()))
+			fmt.Fprintf(w, "🚫 Failed to run command.\n%s\n", strings.TrimSpace(err.Error()))
 			os.Exit(1)
 		} else {
 			duration := time.Since(startTime).Seconds()
-			fmt.Printf("✅ Done in %.3fs\n", duration)
+			fmt.Fprintf(w, "✅ Done in %.3fs\n", duration)
 		}
 	}
 }
 
-func loadProjectFromCommand(cmd *cobra.Command) (content dictionary.ContentRepresentation, meta dictionary.Metadata, err error) {
+// openReportOutput returns the file to write a report to, or stdout if path is empty.
+// Colors are disabled until the returned function is called to close the file,
+// as escape codes would be written to the file.
+func openReportOutput(path string) (io.Writer, func(), error) {
+	if path == "" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.OpenFile(path, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, os.ModePerm)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open output file")
+	}
+	noColor := color.NoColor
+	color.NoColor = true
+	return file, func() {
+		color.NoColor = noColor
+		file.Close()
+	}, nil
+}
+
+func loadProjectFromCommand(cmd *cobra.Command) (
+	content dictionary.ContentRepresentation, meta dictionary.Metadata, positions dictionary.ContentPositions, err error,
+) {
 	projectRoot, err := getProjectRoot(cmd)
 	if err != nil {
 		err = errors.Wrap(err, "failed to resolve project root")
 		return
 	}
-	content, meta, err = loadProject(projectRoot)
+	content, meta, positions, err = loadProjectWithPositions(projectRoot)
 	if err != nil {
 		err = errors.Wrap(err, "failed to load project")
 	}
@@ -41,35 +75,155 @@ func loadProjectFromCommand(cmd *cobra.Command) (content dictionary.ContentRepre
 }
 
 func loadProject(projectRoot string) (content dictionary.ContentRepresentation, meta dictionary.Metadata, err error) {
-	jsonImporter := importer.JsonDictionaryImporter{}
+	content, meta, _, err = loadProjectWithPositions(projectRoot)
+	return
+}
+
+// loadProjectWithPositions is same as loadProject, but also returns the positions of entries in the content files,
+// so that problems of entries can be reported with their lines.
+// Content of JSON projects is assembled from the files of the content layout of the metadata.
+func loadProjectWithPositions(projectRoot string) (
+	content dictionary.ContentRepresentation, meta dictionary.Metadata, positions dictionary.ContentPositions, err error,
+) {
+	files := resolveProjectFiles(projectRoot)
+	projectImporter := loadImporter(files.format)
 
-	metaFile, err := jsonImporter.OpenMetadataFile(projectRoot)
+	metaFile, err := projectImporter.OpenMetadataFile(projectRoot)
 	if err != nil {
 		err = errors.Wrap(err, "failed to open metadata file")
 		return
 	}
 	defer metaFile.Close()
 
-	contentFile, err := jsonImporter.OpenContentFile(projectRoot)
+	meta, err = projectImporter.ImportMetadata(metaFile)
 	if err != nil {
-		err = errors.Wrap(err, "failed to open content file")
+		err = errors.Wrap(err, "failed to read metadata file")
+		return
+	}
+	if err = meta.ContentLayout.Validate(); err != nil {
+		err = errors.Wrap(err, "invalid content layout")
 		return
 	}
-	defer contentFile.Close()
 
-	meta, err = jsonImporter.ImportMetadata(metaFile)
+	if jsonImporter, ok := projectImporter.(importer.JsonDictionaryImporter); ok {
+		content, positions, err = jsonImporter.ImportProjectContent(projectRoot, meta.ContentLayout, func(name string) string {
+			return projectFilePath(projectRoot, name)
+		})
+		if err != nil {
+			err = errors.Wrap(err, "failed to read content file")
+		}
+		return
+	}
+	if !meta.ContentLayout.IsSingle() {
+		err = errors.Errorf("content layouts are only supported by JSON projects")
+		return
+	}
+
+	contentFile, err := projectImporter.OpenContentFile(projectRoot)
 	if err != nil {
-		err = errors.Wrap(err, "failed to read metadata file")
+		err = errors.Wrap(err, "failed to open content file")
 		return
 	}
-	content, err = jsonImporter.ImportContent(contentFile, meta)
+	defer contentFile.Close()
+
+	if positionImporter, ok := projectImporter.(importer.ContentPositionImporter); ok {
+		content, positions, err = positionImporter.ImportContentWithPositions(contentFile, projectFilePath(projectRoot, files.content))
+	} else {
+		content, err = projectImporter.ImportContent(contentFile, meta)
+	}
 	if err != nil {
 		err = errors.Wrap(err, "failed to read content file")
-		return
 	}
 	return
 }
 
+// projectFormats are the formats which projects can be written in, in the order of precedence,
+// with the candidates of the names of the metadata and content files.
+var projectFormats = []struct {
+	format   string
+	metadata []string
+	content  []string
+}{
+	{"json", []string{"metadata.json"}, []string{"content.json"}},
+	{"yaml", []string{"metadata.yaml", "metadata.yml"}, []string{"content.yaml", "content.yml"}},
+	{"toml", []string{"metadata.toml"}, []string{"content.toml"}},
+}
+
+// projectFiles are the format of a project and the names of its files.
+type projectFiles struct {
+	format   string
+	metadata string
+	content  string
+}
+
+// resolveProjectFiles finds the format of a project by its metadata file. JSON is used if there is none.
+func resolveProjectFiles(projectRoot string) projectFiles {
+	for _, candidate := range projectFormats {
+		if metadata, ok := firstExistingFile(projectRoot, candidate.metadata); ok {
+			content, _ := firstExistingFile(projectRoot, candidate.content)
+			return projectFiles{format: candidate.format, metadata: metadata, content: content}
+		}
+	}
+	return projectFiles{format: "json", metadata: "metadata.json", content: "content.json"}
+}
+
+// contentPath returns the path of the content file or directory relative to the project root.
+func (p projectFiles) contentPath(meta dictionary.Metadata) string {
+	if p.format == "json" {
+		return meta.ContentLayout.Path()
+	}
+	return p.content
+}
+
+// firstExistingFile returns the first file of names existing in directory, or the first name if none exists.
+func firstExistingFile(directory string, names []string) (string, bool) {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(directory, name)); err == nil {
+			return name, true
+		}
+	}
+	return names[0], false
+}
+
+// projectExporter returns the exporter which writes a project in its format.
+func projectExporter(projectRoot string) exporter.DictionaryProjectExporter {
+	return loadFileDirectoryExporter(resolveProjectFiles(projectRoot).format)
+}
+
+// validateContent validates content, and returns the errors with the positions of their entries.
+func validateContent(
+	cmd *cobra.Command, content dictionary.ContentRepresentation, meta dictionary.Metadata, positions dictionary.ContentPositions,
+) *multierror.Error {
+	return content.Diagnose(meta, contentValidationOptions(cmd)).WithPositions(positions).ToMultiError()
+}
+
+// contentValidationOptions returns the content validation options given by flags of the command.
+func contentValidationOptions(cmd *cobra.Command) dictionary.ContentValidationOptions {
+	strict, _ := cmd.Flags().GetBool("strict-templates")
+	return dictionary.ContentValidationOptions{StrictTemplateKeys: strict}
+}
+
+func addStrictTemplatesFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(
+		"strict-templates", false,
+		"Require every language of an entry to use the same template keys, except for keys listed in 'omitted_templates'",
+	)
+}
+
+// projectFilePath returns the path of a file in the project relative to the working directory,
+// which is used for reporting problems in the file.
+func projectFilePath(projectRoot, name string) string {
+	filePath := filepath.Join(projectRoot, name)
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return filePath
+	}
+	if relPath, err := filepath.Rel(workingDir, filePath); err == nil {
+		return filepath.ToSlash(relPath)
+	}
+	return filePath
+}
+
 func getProjectRoot(cmd *cobra.Command) (string, error) {
 	projectRoot, _ := cmd.Flags().GetString("project")
 	if projectRoot == "" {
@@ -99,7 +253,7 @@ func readContentFile(metadata dictionary.Metadata, format, filePath string) (dic
 
 	if isDirectory {
 		fullImporter := loadImporter(format)
-		if importer == nil {
+		if fullImporter == nil {
 			return nil, errors.Errorf("unknown import format '%s'", format)
 		}
 		file, err = fullImporter.OpenContentFile(filePath)
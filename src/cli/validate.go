@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const validateCommandDescription = `
+validate checks the metadata and content files for errors, and prints every problem found
+with its line and column in the file. Duplicate keys in the content files are also reported.
+
+The output format is given by '--format':
+  text    human readable lines (default)
+  json    an array of problems with their code, severity, key, language, template key and position
+  sarif   a SARIF 2.1.0 log, for code scanning tools
+  github  workflow commands of GitHub Actions, which annotate the lines of the problems
+
+The command fails if any error is found.`
+
+func execValidateCommand(cmd *cobra.Command, _ []string) error {
+	projectRoot, err := getProjectRoot(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve project root")
+	}
+	content, meta, positions, err := loadProjectWithPositions(projectRoot)
+	if err != nil {
+		// Problems of the content file, such as duplicate keys, are reported as diagnostics.
+		var diagnosticsErr dictionary.DiagnosticsError
+		if !errors.As(err, &diagnosticsErr) {
+			return errors.Wrap(err, "failed to load project")
+		}
+		if err := outputDiagnostics(cmd, diagnosticsErr.Diagnostics); err != nil {
+			return err
+		}
+		return errors.Errorf("%d errors found", diagnosticsErr.Diagnostics.Count(dictionary.SeverityError))
+	}
+
+	files := resolveProjectFiles(projectRoot)
+	diagnostics := meta.Diagnose().WithFile(projectFilePath(projectRoot, files.metadata))
+	// Content cannot be validated correctly with invalid metadata.
+	if diagnostics.Count(dictionary.SeverityError) == 0 {
+		contentDiagnostics := content.Diagnose(meta, contentValidationOptions(cmd)).
+			WithFile(projectFilePath(projectRoot, files.contentPath(meta))).
+			WithPositions(positions)
+		diagnostics = append(diagnostics, contentDiagnostics...)
+	}
+
+	if err := outputDiagnostics(cmd, diagnostics); err != nil {
+		return err
+	}
+	if errorCount := diagnostics.Count(dictionary.SeverityError); errorCount > 0 {
+		return errors.Errorf("%d errors found", errorCount)
+	}
+	return nil
+}
+
+func initValidateCommand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "validate [--format text|json|sarif|github] [--strict-templates]",
+		Short: "Check metadata and content for errors",
+		Long:  validateCommandDescription,
+		Args:  cobra.NoArgs,
+		Run:   wrapReportCommand(execValidateCommand),
+	}
+	addDiagnosticFormatFlag(cmd)
+	addStrictTemplatesFlag(cmd)
+	return cmd
+}
@@ -51,6 +51,8 @@ func (i *IndentedCodeBuilder) Build(w io.Writer) {
 			indentLevel += "  "
 		} else if _, ok := cmd.(icbUnindentCommand); ok {
 			indentLevel = indentLevel[0 : len(indentLevel)-2]
+		} else if cmd == "" {
+			w.Write([]byte("\n"))
 		} else {
 			w.Write([]byte(fmt.Sprintf("%s%s\n", indentLevel, cmd)))
 		}
@@ -0,0 +1,316 @@
+package dictionary
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// cldrPluralRuleSet is a group of locales sharing the same cardinal plural rules.
+// Rules are taken from CLDR's plurals.xml, without samples.
+// The "other" category is implicit and matches anything not matched by the rules.
+type cldrPluralRuleSet struct {
+	locales string
+	rules   [][2]string
+}
+
+var cldrPluralRuleSets = []cldrPluralRuleSet{
+	{
+		locales: "bm bo dz hnj id ig ii in ja jbo jv jw kde kea km ko lkt lo ms my nqo osa root sah ses sg su th to tpi vi wo yo yue zh",
+		rules:   [][2]string{},
+	},
+	{
+		locales: "am as bn doi fa gu hi kn pcm zu",
+		rules:   [][2]string{{"one", "i = 0 or n = 1"}},
+	},
+	{
+		locales: "ff hy kab",
+		rules:   [][2]string{{"one", "i = 0,1"}},
+	},
+	{
+		locales: "ast de en et fi fy gl ia io ji lij nl sc sv sw ur yi",
+		rules:   [][2]string{{"one", "i = 1 and v = 0"}},
+	},
+	{
+		locales: "si",
+		rules:   [][2]string{{"one", "n = 0,1 or i = 0 and f = 1"}},
+	},
+	{
+		locales: "ak bho guw ln mg nso pa ti wa",
+		rules:   [][2]string{{"one", "n = 0..1"}},
+	},
+	{
+		locales: "tzm",
+		rules:   [][2]string{{"one", "n = 0..1 or n = 11..99"}},
+	},
+	{
+		locales: "af an asa az bal bem bez bg brx ce cgg chr ckb dv ee el eo eu fo fur gsw ha haw hu jgo jmc ka kaj kcg kk kkj kl ks ksb ku ky lb lg mas mgo ml mn mr nah nb nd ne nn nnh no nr ny nyn om or os pap ps rm rof rwk saq sd sdh seh sn so sq ss ssy st syr ta te teo tig tk tn tr ts ug uz ve vo vun wae xh xog",
+		rules:   [][2]string{{"one", "n = 1"}},
+	},
+	{
+		locales: "da",
+		rules:   [][2]string{{"one", "n = 1 or t != 0 and i = 0,1"}},
+	},
+	{
+		locales: "is",
+		rules:   [][2]string{{"one", "t = 0 and i % 10 = 1 and i % 100 != 11 or t % 10 = 1 and t % 100 != 11"}},
+	},
+	{
+		locales: "mk",
+		rules:   [][2]string{{"one", "v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11"}},
+	},
+	{
+		locales: "ceb fil tl",
+		rules:   [][2]string{{"one", "v = 0 and i = 1,2,3 or v = 0 and i % 10 != 4,6,9 or v != 0 and f % 10 != 4,6,9"}},
+	},
+	{
+		locales: "lv prg",
+		rules: [][2]string{
+			{"zero", "n % 10 = 0 or n % 100 = 11..19 or v = 2 and f % 100 = 11..19"},
+			{"one", "n % 10 = 1 and n % 100 != 11 or v = 2 and f % 10 = 1 and f % 100 != 11 or v != 2 and f % 10 = 1"},
+		},
+	},
+	{
+		locales: "lag",
+		rules: [][2]string{
+			{"zero", "n = 0"},
+			{"one", "i = 0,1 and n != 0"},
+		},
+	},
+	{
+		locales: "ksh",
+		rules: [][2]string{
+			{"zero", "n = 0"},
+			{"one", "n = 1"},
+		},
+	},
+	{
+		locales: "he",
+		rules: [][2]string{
+			{"one", "i = 1 and v = 0 or i = 0 and v != 0"},
+			{"two", "i = 2 and v = 0"},
+		},
+	},
+	{
+		locales: "iu naq sat se sma smi smj smn sms",
+		rules: [][2]string{
+			{"one", "n = 1"},
+			{"two", "n = 2"},
+		},
+	},
+	{
+		locales: "shi",
+		rules: [][2]string{
+			{"one", "i = 0 or n = 1"},
+			{"few", "n = 2..10"},
+		},
+	},
+	{
+		locales: "mo ro",
+		rules: [][2]string{
+			{"one", "i = 1 and v = 0"},
+			{"few", "v != 0 or n = 0 or n != 1 and n % 100 = 1..19"},
+		},
+	},
+	{
+		locales: "bs hr sh sr",
+		rules: [][2]string{
+			{"one", "v = 0 and i % 10 = 1 and i % 100 != 11 or f % 10 = 1 and f % 100 != 11"},
+			{"few", "v = 0 and i % 10 = 2..4 and i % 100 != 12..14 or f % 10 = 2..4 and f % 100 != 12..14"},
+		},
+	},
+	{
+		locales: "fr",
+		rules: [][2]string{
+			{"one", "i = 0,1"},
+			{"many", "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5"},
+		},
+	},
+	{
+		locales: "pt",
+		rules: [][2]string{
+			{"one", "i = 0..1"},
+			{"many", "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5"},
+		},
+	},
+	{
+		locales: "ca it pt-PT vec",
+		rules: [][2]string{
+			{"one", "i = 1 and v = 0"},
+			{"many", "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5"},
+		},
+	},
+	{
+		locales: "es",
+		rules: [][2]string{
+			{"one", "n = 1"},
+			{"many", "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5"},
+		},
+	},
+	{
+		locales: "gd",
+		rules: [][2]string{
+			{"one", "n = 1,11"},
+			{"two", "n = 2,12"},
+			{"few", "n = 3..10,13..19"},
+		},
+	},
+	{
+		locales: "sl",
+		rules: [][2]string{
+			{"one", "v = 0 and i % 100 = 1"},
+			{"two", "v = 0 and i % 100 = 2"},
+			{"few", "v = 0 and i % 100 = 3..4 or v != 0"},
+		},
+	},
+	{
+		locales: "dsb hsb",
+		rules: [][2]string{
+			{"one", "v = 0 and i % 100 = 1 or f % 100 = 1"},
+			{"two", "v = 0 and i % 100 = 2 or f % 100 = 2"},
+			{"few", "v = 0 and i % 100 = 3..4 or f % 100 = 3..4"},
+		},
+	},
+	{
+		locales: "cs sk",
+		rules: [][2]string{
+			{"one", "i = 1 and v = 0"},
+			{"few", "i = 2..4 and v = 0"},
+			{"many", "v != 0"},
+		},
+	},
+	{
+		locales: "pl",
+		rules: [][2]string{
+			{"one", "i = 1 and v = 0"},
+			{"few", "v = 0 and i % 10 = 2..4 and i % 100 != 12..14"},
+			{"many", "v = 0 and i != 1 and i % 10 = 0..1 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 12..14"},
+		},
+	},
+	{
+		locales: "be",
+		rules: [][2]string{
+			{"one", "n % 10 = 1 and n % 100 != 11"},
+			{"few", "n % 10 = 2..4 and n % 100 != 12..14"},
+			{"many", "n % 10 = 0 or n % 10 = 5..9 or n % 100 = 11..14"},
+		},
+	},
+	{
+		locales: "lt",
+		rules: [][2]string{
+			{"one", "n % 10 = 1 and n % 100 != 11..19"},
+			{"few", "n % 10 = 2..9 and n % 100 != 11..19"},
+			{"many", "f != 0"},
+		},
+	},
+	{
+		locales: "ru uk",
+		rules: [][2]string{
+			{"one", "v = 0 and i % 10 = 1 and i % 100 != 11"},
+			{"few", "v = 0 and i % 10 = 2..4 and i % 100 != 12..14"},
+			{"many", "v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14"},
+		},
+	},
+	{
+		locales: "br",
+		rules: [][2]string{
+			{"one", "n % 10 = 1 and n % 100 != 11,71,91"},
+			{"two", "n % 10 = 2 and n % 100 != 12,72,92"},
+			{"few", "n % 10 = 3..4,9 and n % 100 != 10..19,70..79,90..99"},
+			{"many", "n != 0 and n % 1000000 = 0"},
+		},
+	},
+	{
+		locales: "mt",
+		rules: [][2]string{
+			{"one", "n = 1"},
+			{"two", "n = 2"},
+			{"few", "n = 0 or n % 100 = 3..10"},
+			{"many", "n % 100 = 11..19"},
+		},
+	},
+	{
+		locales: "ga",
+		rules: [][2]string{
+			{"one", "n = 1"},
+			{"two", "n = 2"},
+			{"few", "n = 3..6"},
+			{"many", "n = 7..10"},
+		},
+	},
+	{
+		locales: "gv",
+		rules: [][2]string{
+			{"one", "v = 0 and i % 10 = 1"},
+			{"two", "v = 0 and i % 10 = 2"},
+			{"few", "v = 0 and i % 100 = 0,20,40,60,80"},
+			{"many", "v != 0"},
+		},
+	},
+	{
+		locales: "kw",
+		rules: [][2]string{
+			{"zero", "n = 0"},
+			{"one", "n = 1"},
+			{"two", "n % 100 = 2,22,42,62,82 or n % 1000 = 0 and n % 100000 = 1000..20000,40000,60000,80000 or n != 0 and n % 1000000 = 100000"},
+			{"few", "n % 100 = 3,23,43,63,83"},
+			{"many", "n != 1 and n % 100 = 1,21,41,61,81"},
+		},
+	},
+	{
+		locales: "ar ars",
+		rules: [][2]string{
+			{"zero", "n = 0"},
+			{"one", "n = 1"},
+			{"two", "n = 2"},
+			{"few", "n % 100 = 3..10"},
+			{"many", "n % 100 = 11..99"},
+		},
+	},
+	{
+		locales: "cy",
+		rules: [][2]string{
+			{"zero", "n = 0"},
+			{"one", "n = 1"},
+			{"two", "n = 2"},
+			{"few", "n = 3"},
+			{"many", "n = 6"},
+		},
+	},
+}
+
+var cldrPluralRules = buildCldrPluralRules()
+
+func buildCldrPluralRules() map[string][]PluralRule {
+	result := map[string][]PluralRule{}
+	for _, set := range cldrPluralRuleSets {
+		rules := make([]PluralRule, 0, len(set.rules))
+		for _, rule := range set.rules {
+			condition, err := ParsePluralCondition(rule[1])
+			if err != nil {
+				panic(fmt.Sprintf("invalid CLDR plural data for '%s': %s", set.locales, err))
+			}
+			rules = append(rules, PluralRule{Category: PluralCategory(rule[0]), Condition: condition})
+		}
+		for _, locale := range strings.Fields(set.locales) {
+			result[locale] = rules
+		}
+	}
+	return result
+}
+
+// CldrPluralRules returns the CLDR cardinal plural rules for a language.
+// If the exact language tag has no data, the base language is used instead (ie. 'pt' for 'pt-BR').
+func CldrPluralRules(lang string) ([]PluralRule, bool) {
+	if rules, ok := cldrPluralRules[lang]; ok {
+		return rules, true
+	}
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return nil, false
+	}
+	base, _ := tag.Base()
+	rules, ok := cldrPluralRules[base.String()]
+	return rules, ok
+}
@@ -16,6 +16,7 @@ const (
 
 var templateParenRegex = regexp.MustCompile(TemplateParenPattern)
 var templateOptionRegex = regexp.MustCompile(TemplateOptionPattern)
+var templateKeyNameRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
 
 type ContentRepresentation interface {
 	// ToFlattened returns the corresponding flattened ContentRepresentation.
@@ -25,10 +26,54 @@ type ContentRepresentation interface {
 	// ToFlattened returns the corresponding tree ContentRepresentation.
 	ToTree() *ContentNode
 	Validate(metadata Metadata, options ContentValidationOptions) *multierror.Error
+	// Diagnose is same as Validate, but returns all problems found as diagnostics.
+	Diagnose(metadata Metadata, options ContentValidationOptions) Diagnostics
+}
+
+const (
+	// ContextField is the field of an entry describing the text for translators.
+	ContextField = "context"
+	// OmittedTemplatesField is the field of an entry listing the template keys
+	// intentionally omitted by languages, such as `ko:NAME,COUNT; ja:NAME`.
+	OmittedTemplatesField = "omitted_templates"
+	// TranslationStateField is the field of an entry listing the translation states of languages,
+	// such as `ko:machine-translated; ja:machine-translated`.
+	TranslationStateField = "translation_state"
+)
+
+// IsEntryMetaField returns whether a field of an entry is not the text of a language.
+func IsEntryMetaField(key string) bool {
+	return key == ContextField || key == OmittedTemplatesField || key == TranslationStateField
 }
 
 type Entry map[string]string
 
+// OmittedTemplateKeys parses the omitted template keys of the entry, keyed by language.
+func (e Entry) OmittedTemplateKeys() (map[string]map[string]struct{}, error) {
+	omitted := map[string]map[string]struct{}{}
+	for _, item := range strings.Split(e[OmittedTemplatesField], ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		lang, keys, found := strings.Cut(item, ":")
+		lang = strings.TrimSpace(lang)
+		if !found || lang == "" {
+			return nil, errors.Errorf("invalid omitted templates '%s': expected 'language:KEY,KEY'", strings.TrimSpace(item))
+		}
+		if _, ok := omitted[lang]; !ok {
+			omitted[lang] = map[string]struct{}{}
+		}
+		for _, key := range strings.Split(keys, ",") {
+			key = strings.TrimSpace(key)
+			if !templateKeyNameRegex.MatchString(key) {
+				return nil, errors.Errorf("invalid template key '%s' in omitted templates of '%s'", key, lang)
+			}
+			omitted[lang][key] = struct{}{}
+		}
+	}
+	return omitted, nil
+}
+
 func (e Entry) TemplateKeys(key string) (map[string]TemplateKeyFormat, error) {
 	templates := map[string]TemplateKeyFormat{}
 	for _, template := range templateParenRegex.FindAllString(e[key], -1) {
@@ -73,6 +118,44 @@ func (e Entry) ReplacedTemplateValue(key string, replaceFn func(string, Template
 	return replaced, err
 }
 
+// ReplacedTemplateValueEscaped is same as ReplacedTemplateValue, but the text outside of templates
+// is passed through escapeFn. This is used to build string literals with embedded expressions.
+func (e Entry) ReplacedTemplateValueEscaped(
+	key string,
+	escapeFn func(string) string,
+	replaceFn func(string, TemplateKeyFormat) (string, error),
+) (string, error) {
+	text := e[key]
+	builder := strings.Builder{}
+	lastIndex := 0
+	for _, location := range templateParenRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(escapeFn(text[lastIndex:location[0]]))
+		lastIndex = location[1]
+
+		template := text[location[0]:location[1]]
+		groups := templateOptionRegex.FindStringSubmatch(template)
+		if groups == nil {
+			return "", errors.Errorf("invalid template '%s'", template)
+		}
+		keyFormat, err := ParseTemplateKeyFormat(groups[2], groups[3])
+		if err != nil {
+			return "", err
+		}
+		replaced, err := replaceFn(groups[1], keyFormat)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(replaced)
+	}
+	builder.WriteString(escapeFn(text[lastIndex:]))
+	return builder.String(), nil
+}
+
+// TemplateStrippedText returns text with all templates removed.
+func TemplateStrippedText(text string) string {
+	return templateParenRegex.ReplaceAllString(text, "")
+}
+
 func (e Entry) String() string {
 	keys := make([]string, 0, len(e))
 	for k := range e {
@@ -0,0 +1,158 @@
+package dictionary
+
+import (
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+type Severity string
+
+const (
+	// SeverityOff is only used for turning off lint rules.
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+func (s Severity) Valid() bool {
+	switch s {
+	case SeverityOff, SeverityInfo, SeverityWarning, SeverityError:
+		return true
+	default:
+		return false
+	}
+}
+
+// DiagnosticCode identifies the kind of a diagnostic.
+type DiagnosticCode string
+
+const (
+	CodeInvalidMetadata           DiagnosticCode = "invalid-metadata"
+	CodeInvalidSyntax             DiagnosticCode = "invalid-syntax"
+	CodeDuplicateKey              DiagnosticCode = "duplicate-key"
+	CodeInvalidKey                DiagnosticCode = "invalid-key"
+	CodeInvalidField              DiagnosticCode = "invalid-field"
+	CodeMissingRequiredLanguage   DiagnosticCode = "missing-required-language"
+	CodeUnsupportedLanguage       DiagnosticCode = "unsupported-language"
+	CodeInvalidTemplate           DiagnosticCode = "invalid-template"
+	CodeInvalidPluralTemplate     DiagnosticCode = "invalid-plural-template"
+	CodeIncompatibleTemplateTypes DiagnosticCode = "incompatible-template-types"
+	CodeTemplateKeyMismatch       DiagnosticCode = "template-key-mismatch"
+	CodeInvalidOmittedTemplates   DiagnosticCode = "invalid-omitted-templates"
+	CodeInvalidTranslationState   DiagnosticCode = "invalid-translation-state"
+	CodeMergeConflict             DiagnosticCode = "merge-conflict"
+)
+
+// Diagnostic is a problem found in a project.
+// Key, Language and TemplateKey are empty if the problem is not specific to them.
+type Diagnostic struct {
+	Code        DiagnosticCode
+	Severity    Severity
+	Message     string
+	Key         EntryKey
+	Language    string
+	TemplateKey string
+	Position    SourcePosition
+}
+
+func (d Diagnostic) Error() string {
+	return d.Message
+}
+
+type Diagnostics []Diagnostic
+
+// Count returns the number of diagnostics with a severity.
+func (d Diagnostics) Count(severity Severity) int {
+	count := 0
+	for _, diagnostic := range d {
+		if diagnostic.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// FirstError returns the first diagnostic with the error severity, or nil if there is none.
+func (d Diagnostics) FirstError() error {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			return diagnostic
+		}
+	}
+	return nil
+}
+
+// ToMultiError converts diagnostics with the error severity to errors, prefixed by their position and key.
+func (d Diagnostics) ToMultiError() (err *multierror.Error) {
+	for _, diagnostic := range d {
+		if diagnostic.Severity != SeverityError {
+			continue
+		}
+		var diagnosticErr error = diagnostic
+		if diagnostic.Key != "" {
+			diagnosticErr = errors.Wrapf(diagnosticErr, "invalid content '%s'", diagnostic.Key)
+		}
+		if diagnostic.Position.Line > 0 {
+			diagnosticErr = errors.Wrap(diagnosticErr, diagnostic.Position.String())
+		}
+		err = multierror.Append(err, diagnosticErr)
+	}
+	return
+}
+
+// DiagnosticsError is an error consisting of diagnostics, such as problems found while reading a file.
+type DiagnosticsError struct {
+	Diagnostics Diagnostics
+}
+
+func (d DiagnosticsError) Error() string {
+	return d.Diagnostics.ToMultiError().Error()
+}
+
+// WithFile sets the file of positions of the diagnostics.
+func (d Diagnostics) WithFile(file string) Diagnostics {
+	for index := range d {
+		d[index].Position.File = file
+	}
+	return d
+}
+
+// WithPositions sets the positions of diagnostics to the positions of their entries,
+// or the fields of their languages if known. Diagnostics of unknown entries are not changed.
+func (d Diagnostics) WithPositions(positions ContentPositions) Diagnostics {
+	for index, diagnostic := range d {
+		if diagnostic.Key == "" {
+			continue
+		}
+		if position, ok := positions.Of(diagnostic.Key, diagnostic.Language); ok {
+			d[index].Position = position
+		}
+	}
+	return d
+}
+
+// Sort sorts the diagnostics by file, position, key, language and code.
+func (d Diagnostics) Sort() {
+	sort.SliceStable(d, func(i, j int) bool {
+		a, b := d[i], d[j]
+		if a.Position.File != b.Position.File {
+			return a.Position.File < b.Position.File
+		}
+		if a.Position.Line != b.Position.Line {
+			return a.Position.Line < b.Position.Line
+		}
+		if a.Position.Column != b.Position.Column {
+			return a.Position.Column < b.Position.Column
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		return a.Code < b.Code
+	})
+}
@@ -1,5 +1,7 @@
 package dictionary
 
+import "sort"
+
 type DiffFlag string
 
 const (
@@ -8,10 +10,21 @@ const (
 	ChangeFlag DiffFlag = "CHANGE"
 )
 
+// FieldDifference is the difference of a field of an entry. Old is empty for created fields,
+// and New is empty for deleted fields.
+type FieldDifference struct {
+	Flag DiffFlag
+	Old  string
+	New  string
+}
+
 type ContentDifference struct {
-	CreatedKeys       []EntryKey
-	DeletedKeys       []EntryKey
-	Changes           map[EntryKey]map[string]DiffFlag
+	CreatedKeys []EntryKey
+	DeletedKeys []EntryKey
+	// CreatedEntries and DeletedEntries have the entries of CreatedKeys and DeletedKeys.
+	CreatedEntries    map[EntryKey]Entry
+	DeletedEntries    map[EntryKey]Entry
+	Changes           map[EntryKey]map[string]FieldDifference
 	SourceKeyCount    int
 	DestKeyCount      int
 	UnchangedKeyCount int
@@ -22,44 +35,46 @@ func DiffContents(from, to ContentRepresentation) ContentDifference {
 	diff := ContentDifference{
 		CreatedKeys:       []EntryKey{},
 		DeletedKeys:       []EntryKey{},
-		Changes:           map[EntryKey]map[string]DiffFlag{},
+		CreatedEntries:    map[EntryKey]Entry{},
+		DeletedEntries:    map[EntryKey]Entry{},
+		Changes:           map[EntryKey]map[string]FieldDifference{},
 		SourceKeyCount:    len(*flatFrom),
 		DestKeyCount:      len(*flatTo),
 		UnchangedKeyCount: 0,
 	}
 
-	for key := range *flatFrom {
+	for key, entry := range *flatFrom {
 		if _, ok := (*flatTo)[key]; !ok {
-			diff.DeletedKeys = append(diff.DeletedKeys, key)
+			diff.addDeleted(key, entry)
 		}
 	}
-	for key := range *flatTo {
+	for key, entry := range *flatTo {
 		if _, ok := (*flatFrom)[key]; !ok {
-			diff.CreatedKeys = append(diff.CreatedKeys, key)
+			diff.addCreated(key, entry)
 		} else {
 			if len((*flatTo)[key]) == 0 && len((*flatFrom)[key]) == 0 {
 				continue
 			}
 			if len((*flatTo)[key]) == 0 {
-				diff.DeletedKeys = append(diff.DeletedKeys, key)
+				diff.addDeleted(key, (*flatFrom)[key])
 				continue
 			}
 			if len((*flatFrom)[key]) == 0 {
-				diff.CreatedKeys = append(diff.CreatedKeys, key)
+				diff.addCreated(key, entry)
 				continue
 			}
 
-			diff.Changes[key] = map[string]DiffFlag{}
-			for lang := range (*flatFrom)[key] {
+			diff.Changes[key] = map[string]FieldDifference{}
+			for lang, langFromValue := range (*flatFrom)[key] {
 				if _, ok := (*flatTo)[key][lang]; !ok {
-					diff.Changes[key][lang] = DeleteFlag
+					diff.Changes[key][lang] = FieldDifference{Flag: DeleteFlag, Old: langFromValue}
 				}
 			}
 			for lang, langValue := range (*flatTo)[key] {
 				if langFromValue, ok := (*flatFrom)[key][lang]; !ok {
-					diff.Changes[key][lang] = CreateFlag
+					diff.Changes[key][lang] = FieldDifference{Flag: CreateFlag, New: langValue}
 				} else if langValue != langFromValue {
-					diff.Changes[key][lang] = ChangeFlag
+					diff.Changes[key][lang] = FieldDifference{Flag: ChangeFlag, Old: langFromValue, New: langValue}
 				}
 			}
 			if len(diff.Changes[key]) == 0 {
@@ -70,3 +85,44 @@ func DiffContents(from, to ContentRepresentation) ContentDifference {
 	diff.UnchangedKeyCount = len(*flatTo) - len(diff.Changes) - len(diff.CreatedKeys)
 	return diff
 }
+
+func (c *ContentDifference) addCreated(key EntryKey, entry Entry) {
+	c.CreatedKeys = append(c.CreatedKeys, key)
+	c.CreatedEntries[key] = entry
+}
+
+func (c *ContentDifference) addDeleted(key EntryKey, entry Entry) {
+	c.DeletedKeys = append(c.DeletedKeys, key)
+	c.DeletedEntries[key] = entry
+}
+
+// TemplateKeyChange is a template key added, removed, or changed in type between the texts of a field.
+// OldKind is empty for added keys, and NewKind is empty for removed keys.
+type TemplateKeyChange struct {
+	Key     string
+	OldKind TemplateKeyType
+	NewKind TemplateKeyType
+}
+
+// TemplateChanges returns the changes of the template keys between the old and new texts, sorted by key.
+// Texts with invalid templates are regarded as having no template keys.
+func (f FieldDifference) TemplateChanges() []TemplateKeyChange {
+	oldKeys, _ := Entry{"": f.Old}.TemplateKeys("")
+	newKeys, _ := Entry{"": f.New}.TemplateKeys("")
+
+	changes := []TemplateKeyChange{}
+	for key, oldFormat := range oldKeys {
+		if newFormat, ok := newKeys[key]; !ok {
+			changes = append(changes, TemplateKeyChange{Key: key, OldKind: oldFormat.Kind})
+		} else if newFormat.Kind != oldFormat.Kind {
+			changes = append(changes, TemplateKeyChange{Key: key, OldKind: oldFormat.Kind, NewKind: newFormat.Kind})
+		}
+	}
+	for key, newFormat := range newKeys {
+		if _, ok := oldKeys[key]; !ok {
+			changes = append(changes, TemplateKeyChange{Key: key, NewKind: newFormat.Kind})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
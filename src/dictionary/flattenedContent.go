@@ -1,8 +1,9 @@
 package dictionary
 
 import (
+	"sort"
+
 	"github.com/hashicorp/go-multierror"
-	"github.com/pkg/errors"
 )
 
 type FlattenedContent map[EntryKey]Entry
@@ -41,15 +42,31 @@ func (f *FlattenedContent) ToTree() *ContentNode {
 	return &root
 }
 
-func (f FlattenedContent) Validate(metadata Metadata, options ContentValidationOptions) (err *multierror.Error) {
+func (f FlattenedContent) Validate(metadata Metadata, options ContentValidationOptions) *multierror.Error {
+	return f.Diagnose(metadata, options).ToMultiError()
+}
+
+// Diagnose validates every entry and returns all problems found, in the order of keys.
+func (f FlattenedContent) Diagnose(metadata Metadata, options ContentValidationOptions) Diagnostics {
 	validator := NewContentValidator(metadata, options)
-	for key, entry := range f {
+	keys := make([]EntryKey, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	diagnostics := Diagnostics{}
+	for _, key := range keys {
 		if keyErr := ValidateJoinedKey(key); keyErr != nil {
-			err = multierror.Append(err, errors.Wrapf(keyErr, "invalid content '%s'", key))
-		}
-		if _, entryErr := validator.Validate(entry); entryErr != nil {
-			err = multierror.Append(err, errors.Wrapf(entryErr, "invalid content '%s'", key))
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:     CodeInvalidKey,
+				Severity: SeverityError,
+				Message:  keyErr.Error(),
+				Key:      key,
+			})
 		}
+		_, entryDiagnostics := validator.Diagnose(key, f[key])
+		diagnostics = append(diagnostics, entryDiagnostics...)
 	}
-	return
+	return diagnostics
 }
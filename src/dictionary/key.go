@@ -29,6 +29,12 @@ func (e EntryKey) LastPart() string {
 	return string(e[strings.LastIndex(string(e), ".")+1:])
 }
 
+// TopLevel returns the first part of the key.
+func (e EntryKey) TopLevel() string {
+	top, _, _ := strings.Cut(string(e), ".")
+	return top
+}
+
 func (e EntryKey) Parts() []string {
 	return strings.Split(string(e), ".")
 }
@@ -0,0 +1,79 @@
+package dictionary
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type ContentLayoutType string
+
+const (
+	// ContentLayoutSingle stores all entries in content.json of the project root.
+	ContentLayoutSingle ContentLayoutType = "single"
+	// ContentLayoutKey stores entries in a file for each top-level key, such as `content/screens.json`.
+	// Each file has the same shape as content.json.
+	ContentLayoutKey ContentLayoutType = "key"
+	// ContentLayoutLanguage stores entries in a file for each language, such as `content/en.json`.
+	// Each file maps entry keys to texts. Entry meta fields are stored like languages, such as `content/context.json`.
+	ContentLayoutLanguage ContentLayoutType = "language"
+)
+
+// DefaultContentDirectory is the directory of content files used if the layout does not specify one.
+const DefaultContentDirectory = "content"
+
+// ContentLayout describes how content is split into files, given under the 'content_layout' key of the metadata.
+type ContentLayout struct {
+	// Type is the layout type. ContentLayoutSingle is used if empty.
+	Type ContentLayoutType
+	// Directory is the directory of content files relative to the project root.
+	// It is not used by ContentLayoutSingle.
+	Directory string
+}
+
+func (c ContentLayout) IsZero() bool {
+	return c.Type == "" && c.Directory == ""
+}
+
+// IsSingle returns whether all entries are stored in content.json.
+func (c ContentLayout) IsSingle() bool {
+	return c.Type == "" || c.Type == ContentLayoutSingle
+}
+
+// ContentDirectory returns the directory of content files relative to the project root.
+func (c ContentLayout) ContentDirectory() string {
+	if c.Directory == "" {
+		return DefaultContentDirectory
+	}
+	return path.Clean(c.Directory)
+}
+
+// Path returns the path of the content file, or the directory of content files, relative to the project root.
+func (c ContentLayout) Path() string {
+	if c.IsSingle() {
+		return "content.json"
+	}
+	return c.ContentDirectory()
+}
+
+// FilePath returns the path of the file relative to the project root,
+// which stores the entries of a top-level key or a language depending on the type.
+func (c ContentLayout) FilePath(name string) string {
+	return path.Join(c.ContentDirectory(), name+".json")
+}
+
+func (c ContentLayout) Validate() error {
+	switch c.Type {
+	case "", ContentLayoutSingle, ContentLayoutKey, ContentLayoutLanguage:
+	default:
+		return errors.Errorf("unknown layout type '%s'", c.Type)
+	}
+	if c.Directory != "" {
+		directory := path.Clean(c.Directory)
+		if path.IsAbs(directory) || directory == "." || directory == ".." || strings.HasPrefix(directory, "../") {
+			return errors.Errorf("directory '%s' should be a subdirectory of the project root", c.Directory)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package dictionary
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// LintConfig is the configuration of lint rules, given under the 'lint' key of the metadata.
+type LintConfig struct {
+	// SourceLanguage is the language other languages are compared against.
+	// The first required language is used if empty.
+	SourceLanguage string
+	Rules          map[string]LintRuleConfig
+}
+
+// LintRuleConfig is the configuration of a single lint rule.
+type LintRuleConfig struct {
+	// Severity overrides the default severity of the rule if not empty.
+	Severity Severity
+	// Options are the rule specific options.
+	Options map[string]interface{}
+}
+
+func (l LintConfig) IsZero() bool {
+	return l.SourceLanguage == "" && len(l.Rules) == 0
+}
+
+func (l LintConfig) Validate(supportedLanguages map[string]struct{}) (err *multierror.Error) {
+	if l.SourceLanguage != "" {
+		if _, ok := supportedLanguages[l.SourceLanguage]; !ok {
+			err = multierror.Append(err, errors.Errorf("source language '%s' is not in SupportedLanguages", l.SourceLanguage))
+		}
+	}
+	for name, rule := range l.Rules {
+		if rule.Severity != "" && !rule.Severity.Valid() {
+			err = multierror.Append(err, errors.Errorf("invalid severity '%s' for rule '%s'", rule.Severity, name))
+		}
+	}
+	return
+}
+
+// LintSourceLanguage returns the language which other languages are compared against by lint rules.
+func (m Metadata) LintSourceLanguage() string {
+	if m.Lint.SourceLanguage != "" {
+		return m.Lint.SourceLanguage
+	}
+	if len(m.RequiredLanguages) > 0 {
+		return m.RequiredLanguages[0]
+	}
+	return ""
+}
@@ -1,15 +1,313 @@
 package dictionary
 
+import (
+	"sort"
+	"strings"
+)
+
+// Conflict markers written to texts by MergeResult.MarkConflicts, in the style of diff3.
+const (
+	OursConflictMarker      = "<<<<<<< ours"
+	BaseConflictMarker      = "||||||| base"
+	SeparatorConflictMarker = "======="
+	TheirsConflictMarker    = ">>>>>>> theirs"
+)
+
+// MergeContent merges from into a copy of to, overwriting the fields of to with the fields of from.
 func MergeContent(from, to ContentRepresentation) ContentRepresentation {
-	flatFrom, flatTo := from.ToFlattened(), from.ToNewFlattened()
-	for fromLang, fromEntry := range *flatFrom {
-		if _, ok := (*flatTo)[fromLang]; ok {
-			for lang, langContent := range fromEntry {
-				(*flatTo)[fromLang][lang] = langContent
+	flatFrom, flatTo := from.ToFlattened(), to.ToNewFlattened()
+	for key, fromEntry := range *flatFrom {
+		merged := copyEntry((*flatTo)[key])
+		for lang, langContent := range fromEntry {
+			merged[lang] = langContent
+		}
+		(*flatTo)[key] = merged
+	}
+	return flatTo
+}
+
+// MergeSide is a side of a three-way merge.
+type MergeSide string
+
+const (
+	MergeBase   MergeSide = "base"
+	MergeOurs   MergeSide = "ours"
+	MergeTheirs MergeSide = "theirs"
+)
+
+// MergeValue is the value of a field on a side of a three-way merge.
+type MergeValue struct {
+	Text   string
+	Exists bool
+}
+
+// MergeConflict is a field changed differently on both sides of a three-way merge.
+// If Field is empty, the whole entry is deleted on one side and changed on the other,
+// and the values are the existence of the entry.
+type MergeConflict struct {
+	Key      EntryKey
+	Field    string
+	Base     MergeValue
+	Ours     MergeValue
+	Theirs   MergeValue
+	Resolved bool
+}
+
+// Value returns the value of a side.
+func (m MergeConflict) Value(side MergeSide) MergeValue {
+	switch side {
+	case MergeBase:
+		return m.Base
+	case MergeTheirs:
+		return m.Theirs
+	default:
+		return m.Ours
+	}
+}
+
+// MergeResult is the result of a three-way merge.
+// Content has the fields of ours for conflicts, until they are resolved.
+type MergeResult struct {
+	Content   *FlattenedContent
+	Conflicts []MergeConflict
+
+	base, ours, theirs *FlattenedContent
+}
+
+// ThreeWayMerge merges the changes of ours and theirs from their common base.
+// A field changed on only one side takes the change, and a field changed differently on both sides is a conflict.
+// An entry deleted on one side is deleted, unless it is changed on the other side.
+func ThreeWayMerge(base, ours, theirs ContentRepresentation) *MergeResult {
+	result := &MergeResult{
+		Content:   &FlattenedContent{},
+		Conflicts: []MergeConflict{},
+		base:      base.ToFlattened(),
+		ours:      ours.ToFlattened(),
+		theirs:    theirs.ToFlattened(),
+	}
+
+	for _, key := range mergeKeys(result.base, result.ours, result.theirs) {
+		baseEntry, inBase := (*result.base)[key]
+		oursEntry, inOurs := (*result.ours)[key]
+		theirsEntry, inTheirs := (*result.theirs)[key]
+
+		switch {
+		case inOurs && inTheirs:
+			(*result.Content)[key] = result.mergeEntry(key, baseEntry, oursEntry, theirsEntry)
+		case inOurs:
+			if inBase && entriesEqual(baseEntry, oursEntry) {
+				continue
 			}
+			(*result.Content)[key] = copyEntry(oursEntry)
+			if inBase {
+				result.addEntryConflict(key, inOurs, inTheirs)
+			}
+		case inTheirs:
+			if inBase && entriesEqual(baseEntry, theirsEntry) {
+				continue
+			}
+			if inBase {
+				result.addEntryConflict(key, inOurs, inTheirs)
+			} else {
+				(*result.Content)[key] = copyEntry(theirsEntry)
+			}
+		}
+	}
+	return result
+}
+
+func (m *MergeResult) mergeEntry(key EntryKey, base, ours, theirs Entry) Entry {
+	merged := Entry{}
+	for _, field := range mergeFields(base, ours, theirs) {
+		baseValue, oursValue, theirsValue := fieldValue(base, field), fieldValue(ours, field), fieldValue(theirs, field)
+		value := oursValue
+		if field == TranslationStateField && oursValue != theirsValue && oursValue != baseValue && theirsValue != baseValue {
+			// Statuses of different languages are merged separately, so that only the languages
+			// changed differently on both sides are conflicts.
+			if oursMerged, theirsMerged, conflicting, ok := mergeTranslationStatuses(base, ours, theirs); ok {
+				oursValue, theirsValue, value = oursMerged, theirsMerged, oursMerged
+				if !conflicting {
+					theirsValue = oursValue
+				}
+			}
+		}
+		switch {
+		case oursValue == theirsValue, theirsValue == baseValue:
+		case oursValue == baseValue:
+			value = theirsValue
+		default:
+			m.Conflicts = append(m.Conflicts, MergeConflict{Key: key, Field: field, Base: baseValue, Ours: oursValue, Theirs: theirsValue})
+		}
+		if value.Exists {
+			merged[field] = value.Text
+		}
+	}
+	return merged
+}
+
+func (m *MergeResult) addEntryConflict(key EntryKey, inOurs, inTheirs bool) {
+	m.Conflicts = append(m.Conflicts, MergeConflict{
+		Key:    key,
+		Base:   MergeValue{Exists: true},
+		Ours:   MergeValue{Exists: inOurs},
+		Theirs: MergeValue{Exists: inTheirs},
+	})
+}
+
+// Unresolved returns the indices of the conflicts which are not resolved yet.
+func (m *MergeResult) Unresolved() []int {
+	indices := []int{}
+	for index, conflict := range m.Conflicts {
+		if !conflict.Resolved {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// Resolve resolves a conflict by taking the value of a side.
+func (m *MergeResult) Resolve(index int, side MergeSide) {
+	conflict := &m.Conflicts[index]
+	conflict.Resolved = true
+	if conflict.Field == "" {
+		var sideContent *FlattenedContent
+		switch side {
+		case MergeBase:
+			sideContent = m.base
+		case MergeTheirs:
+			sideContent = m.theirs
+		default:
+			sideContent = m.ours
+		}
+		if entry, ok := (*sideContent)[conflict.Key]; ok {
+			(*m.Content)[conflict.Key] = copyEntry(entry)
 		} else {
-			(*flatTo)[fromLang] = fromEntry
+			delete(*m.Content, conflict.Key)
 		}
+		return
 	}
-	return flatTo
+
+	value := conflict.Value(side)
+	entry := (*m.Content)[conflict.Key]
+	if value.Exists {
+		entry[conflict.Field] = value.Text
+	} else {
+		delete(entry, conflict.Field)
+	}
+}
+
+// ResolveAll resolves all unresolved conflicts by taking the values of a side.
+func (m *MergeResult) ResolveAll(side MergeSide) {
+	for _, index := range m.Unresolved() {
+		m.Resolve(index, side)
+	}
+}
+
+// MarkConflicts writes the values of all sides of unresolved conflicts to the texts with conflict markers,
+// and marks them as resolved. For conflicts of whole entries, every field of the changed entry is marked.
+func (m *MergeResult) MarkConflicts() {
+	for _, index := range m.Unresolved() {
+		conflict := &m.Conflicts[index]
+		conflict.Resolved = true
+		if conflict.Field != "" {
+			(*m.Content)[conflict.Key][conflict.Field] = markedConflictText(conflict.Base, conflict.Ours, conflict.Theirs)
+			continue
+		}
+
+		baseEntry, oursEntry, theirsEntry := (*m.base)[conflict.Key], (*m.ours)[conflict.Key], (*m.theirs)[conflict.Key]
+		marked := Entry{}
+		for _, field := range mergeFields(baseEntry, oursEntry, theirsEntry) {
+			baseValue, oursValue, theirsValue := fieldValue(baseEntry, field), fieldValue(oursEntry, field), fieldValue(theirsEntry, field)
+			if oursValue == theirsValue {
+				marked[field] = oursValue.Text
+			} else {
+				marked[field] = markedConflictText(baseValue, oursValue, theirsValue)
+			}
+		}
+		(*m.Content)[conflict.Key] = marked
+	}
+}
+
+// HasConflictMarkers returns whether a text has conflict markers written by MergeResult.MarkConflicts.
+func HasConflictMarkers(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, OursConflictMarker) || strings.HasPrefix(line, TheirsConflictMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+func markedConflictText(base, ours, theirs MergeValue) string {
+	label := func(marker string, value MergeValue) string {
+		if !value.Exists {
+			return marker + " (deleted)\n"
+		}
+		return marker + "\n"
+	}
+	section := func(value MergeValue) string {
+		if !value.Exists {
+			return ""
+		}
+		return value.Text + "\n"
+	}
+	return label(OursConflictMarker, ours) + section(ours) +
+		label(BaseConflictMarker, base) + section(base) +
+		SeparatorConflictMarker + "\n" + section(theirs) +
+		strings.TrimSuffix(label(TheirsConflictMarker, theirs), "\n")
+}
+
+func fieldValue(entry Entry, field string) MergeValue {
+	text, ok := entry[field]
+	return MergeValue{Text: text, Exists: ok}
+}
+
+func entriesEqual(a, b Entry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for field, text := range a {
+		if other, ok := b[field]; !ok || other != text {
+			return false
+		}
+	}
+	return true
+}
+
+func copyEntry(entry Entry) Entry {
+	copied := make(Entry, len(entry))
+	for field, text := range entry {
+		copied[field] = text
+	}
+	return copied
+}
+
+func mergeKeys(contents ...*FlattenedContent) []EntryKey {
+	keySet := map[EntryKey]struct{}{}
+	for _, content := range contents {
+		for key := range *content {
+			keySet[key] = struct{}{}
+		}
+	}
+	keys := make([]EntryKey, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func mergeFields(entries ...Entry) []string {
+	fieldSet := map[string]struct{}{}
+	for _, entry := range entries {
+		for field := range entry {
+			fieldSet[field] = struct{}{}
+		}
+	}
+	fields := make([]string, 0, len(fieldSet))
+	for field := range fieldSet {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
 }
@@ -1,11 +1,21 @@
 package dictionary
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 )
 
+// pluralCategoryNameRegex is the pattern of category names of custom plural definitions.
+var pluralCategoryNameRegex = regexp.MustCompile(`^[a-z]+$`)
+
 type PluralDefinition struct {
+	// Category is the name used for choosing this definition in plural templates.
+	// Unnamed definitions are referred to by their index.
+	Category   PluralCategory
 	Op         string
 	Operand    int
 	HasOperand bool
@@ -33,6 +43,23 @@ func (p PluralDefinition) Valid() error {
 	}
 }
 
+// CategoryName returns the category of the definition, or its index if it is unnamed.
+func (p PluralDefinition) CategoryName(index int) PluralCategory {
+	if p.Category == "" {
+		return PluralCategory(strconv.Itoa(index))
+	}
+	return p.Category
+}
+
+// IntegerExpression returns the definition as a C-style boolean expression on an integer variable,
+// such as `value % 10 == 1`.
+func (p PluralDefinition) IntegerExpression(variable string) string {
+	if p.HasOperand {
+		return fmt.Sprintf("%s %s %d == %d", variable, p.Op, p.Operand, p.Equals)
+	}
+	return fmt.Sprintf("%s %s %d", variable, p.Op, p.Equals)
+}
+
 func (p PluralDefinition) validateCmp() error {
 	if p.HasOperand {
 		return errors.Errorf("operator '%s' should not have operand", p.Op)
@@ -72,6 +99,11 @@ type Metadata struct {
 	SupportedLanguages []string
 	ExporterOptions    map[string]map[string]interface{}
 	Plurals            map[string][]PluralDefinition
+	// CldrPlurals is the set of languages whose plural rules are derived from CLDR data
+	// instead of a PluralDefinition list.
+	CldrPlurals   map[string]struct{}
+	Lint          LintConfig
+	ContentLayout ContentLayout
 }
 
 func (m Metadata) SupportedLanguageSet() map[string]struct{} {
@@ -133,26 +165,99 @@ func (m Metadata) Validate() (err *multierror.Error) {
 	if plError := m.validatePlurals(&supportedLangSet); plError != nil {
 		err = multierror.Append(err, errors.Wrap(plError, "errors with plural definition"))
 	}
+	if lintError := m.Lint.Validate(supportedLangSet); lintError != nil {
+		err = multierror.Append(err, errors.Wrap(lintError, "errors with lint configuration"))
+	}
+	if layoutError := m.ContentLayout.Validate(); layoutError != nil {
+		err = multierror.Append(err, errors.Wrap(layoutError, "invalid content layout"))
+	}
 	return
 }
 
+// Diagnose is same as Validate, but returns the errors as diagnostics.
+func (m Metadata) Diagnose() Diagnostics {
+	diagnostics := Diagnostics{}
+	if err := m.Validate(); err != nil {
+		for _, validateErr := range err.Errors {
+			diagnostics = append(diagnostics, Diagnostic{
+				Code:     CodeInvalidMetadata,
+				Severity: SeverityError,
+				Message:  validateErr.Error(),
+			})
+		}
+	}
+	return diagnostics
+}
+
 func (m Metadata) validatePlurals(languages *map[string]struct{}) (err *multierror.Error) {
+	for lang := range m.CldrPlurals {
+		if _, ok := (*languages)[lang]; !ok {
+			err = multierror.Append(err, errors.Errorf("language '%s' is defined in plurals but not in SupportedLanguages", lang))
+		}
+		if _, ok := m.Plurals[lang]; ok {
+			err = multierror.Append(err, errors.Errorf("language '%s' has both CLDR and custom plural definitions", lang))
+		}
+		if _, ok := CldrPluralRules(lang); !ok {
+			err = multierror.Append(err, errors.Errorf("no CLDR plural rules for language '%s'", lang))
+		}
+	}
 	for lang, defs := range m.Plurals {
 		if _, ok := (*languages)[lang]; !ok {
 			err = multierror.Append(err, errors.Errorf("language '%s' is defined in plurals but not in SupportedLanguages", lang))
 			continue
 		}
+		categories := map[PluralCategory]struct{}{}
 		for index, def := range defs {
 			if validateErr := def.Valid(); validateErr != nil {
 				err = multierror.Append(err, errors.Wrapf(validateErr, "invalid plural definition for language '%s', index [%d]", lang, index))
 			}
+			if def.Category == "" {
+				continue
+			}
+			if !pluralCategoryNameRegex.MatchString(string(def.Category)) || def.Category == OtherPluralCategory {
+				err = multierror.Append(err, errors.Errorf("invalid plural category '%s' for language '%s', index [%d]", def.Category, lang, index))
+			}
+			if _, ok := categories[def.Category]; ok {
+				err = multierror.Append(err, errors.Errorf("duplicate plural category '%s' for language '%s'", def.Category, lang))
+			}
+			categories[def.Category] = struct{}{}
 		}
 	}
 	return
 }
 
+// PluralRules returns the CLDR plural rules of a language,
+// if the language is configured to use CLDR plural rules.
+func (m Metadata) PluralRules(lang string) ([]PluralRule, bool) {
+	if _, ok := m.CldrPlurals[lang]; !ok {
+		return nil, false
+	}
+	return CldrPluralRules(lang)
+}
+
+// PluralCategories returns the plural categories of a language in the order they are tested.
+// The last category is always "other".
+func (m Metadata) PluralCategories(lang string) []PluralCategory {
+	if rules, ok := m.PluralRules(lang); ok {
+		categories := make([]PluralCategory, 0, len(rules)+1)
+		for _, rule := range rules {
+			categories = append(categories, rule.Category)
+		}
+		return append(categories, OtherPluralCategory)
+	}
+	defs, ok := m.Plurals[lang]
+	if !ok {
+		defs = DefaultPluralDefinition()
+	}
+	categories := make([]PluralCategory, 0, len(defs)+1)
+	for index, def := range defs {
+		categories = append(categories, def.CategoryName(index))
+	}
+	return append(categories, OtherPluralCategory)
+}
+
 func DefaultPluralDefinition() []PluralDefinition {
 	return []PluralDefinition{
-		{Op: "==", Equals: 1},
+		{Category: OnePluralCategory, Op: "==", Equals: 1},
 	}
 }
@@ -0,0 +1,326 @@
+package dictionary
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type PluralCategory string
+
+const (
+	ZeroPluralCategory  PluralCategory = "zero"
+	OnePluralCategory   PluralCategory = "one"
+	TwoPluralCategory   PluralCategory = "two"
+	FewPluralCategory   PluralCategory = "few"
+	ManyPluralCategory  PluralCategory = "many"
+	OtherPluralCategory PluralCategory = "other"
+)
+
+// PluralCategoryOrder is the canonical order of plural categories defined by CLDR.
+var PluralCategoryOrder = []PluralCategory{
+	ZeroPluralCategory,
+	OnePluralCategory,
+	TwoPluralCategory,
+	FewPluralCategory,
+	ManyPluralCategory,
+	OtherPluralCategory,
+}
+
+// PluralOperand is a CLDR plural operand.
+// See https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+//
+//	n: absolute value of the source number
+//	i: integer digits of n
+//	v: number of visible fraction digits in n, with trailing zeros
+//	w: number of visible fraction digits in n, without trailing zeros
+//	f: visible fraction digits in n, with trailing zeros
+//	t: visible fraction digits in n, without trailing zeros
+//	e, c: compact decimal exponent. Always 0, since compact formatting is not supported.
+type PluralOperand byte
+
+func (p PluralOperand) Valid() bool {
+	return strings.IndexByte("niwvftec", byte(p)) != -1
+}
+
+// IsInteger reports whether the operand only depends on the integer part of a number.
+func (p PluralOperand) IsInteger() bool {
+	return p == 'n' || p == 'i'
+}
+
+type PluralRange struct {
+	From int
+	To   int
+}
+
+// PluralRelation is a single relation of a plural rule, such as `i % 10 = 2..4`.
+type PluralRelation struct {
+	Operand PluralOperand
+	// Modulo is the right hand side of the `%` operator. Zero if the relation has no modulo.
+	Modulo  int
+	Negated bool
+	Ranges  []PluralRange
+}
+
+// PluralCondition is a disjunction of conjunctions of relations.
+// The condition matches if all relations of any group match.
+// An empty group always matches, and an empty condition never matches.
+type PluralCondition [][]PluralRelation
+
+// PluralRule selects Category for numbers matching Condition.
+type PluralRule struct {
+	Category  PluralCategory
+	Condition PluralCondition
+}
+
+// PluralOperands holds the CLDR operands of a number.
+type PluralOperands struct {
+	N float64
+	I int
+	V int
+	W int
+	F int
+	T int
+}
+
+// NewPluralOperands calculates the operands of a decimal number string such as "-1.50".
+func NewPluralOperands(value string) (PluralOperands, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "-")
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return PluralOperands{}, errors.Wrapf(err, "invalid number '%s'", value)
+	}
+	intPart, fracPart := value, ""
+	if dot := strings.IndexByte(value, '.'); dot != -1 {
+		intPart, fracPart = value[:dot], value[dot+1:]
+	}
+	trimmedFrac := strings.TrimRight(fracPart, "0")
+
+	ops := PluralOperands{N: n, V: len(fracPart), W: len(trimmedFrac)}
+	if ops.I, err = atoiOrZero(intPart); err != nil {
+		return PluralOperands{}, errors.Wrapf(err, "invalid number '%s'", value)
+	}
+	if ops.F, err = atoiOrZero(fracPart); err != nil {
+		return PluralOperands{}, errors.Wrapf(err, "invalid number '%s'", value)
+	}
+	if ops.T, err = atoiOrZero(trimmedFrac); err != nil {
+		return PluralOperands{}, errors.Wrapf(err, "invalid number '%s'", value)
+	}
+	return ops, nil
+}
+
+func atoiOrZero(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func (p PluralOperands) value(operand PluralOperand) float64 {
+	switch operand {
+	case 'n':
+		return p.N
+	case 'i':
+		return float64(p.I)
+	case 'v':
+		return float64(p.V)
+	case 'w':
+		return float64(p.W)
+	case 'f':
+		return float64(p.F)
+	case 't':
+		return float64(p.T)
+	default:
+		return 0
+	}
+}
+
+// ParsePluralCondition parses a condition written in the CLDR plural rule syntax,
+// such as `v = 0 and i % 10 = 2..4 and i % 100 != 12..14`.
+// Samples (the `@integer` and `@decimal` parts) are ignored.
+func ParsePluralCondition(condition string) (PluralCondition, error) {
+	if sampleIndex := strings.IndexByte(condition, '@'); sampleIndex != -1 {
+		condition = condition[:sampleIndex]
+	}
+	condition = strings.TrimSpace(condition)
+	if condition == "" {
+		return PluralCondition{{}}, nil
+	}
+
+	result := PluralCondition{}
+	for _, orPart := range strings.Split(condition, " or ") {
+		group := []PluralRelation{}
+		for _, andPart := range strings.Split(orPart, " and ") {
+			relation, err := parsePluralRelation(strings.TrimSpace(andPart))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid condition '%s'", condition)
+			}
+			group = append(group, relation)
+		}
+		result = append(result, group)
+	}
+	return result, nil
+}
+
+func parsePluralRelation(relation string) (PluralRelation, error) {
+	result := PluralRelation{}
+	var left, right string
+	if index := strings.Index(relation, "!="); index != -1 {
+		result.Negated = true
+		left, right = relation[:index], relation[index+2:]
+	} else if index := strings.IndexByte(relation, '='); index != -1 {
+		left, right = relation[:index], relation[index+1:]
+	} else {
+		return PluralRelation{}, errors.Errorf("relation '%s' has no operator", relation)
+	}
+
+	operandPart := strings.TrimSpace(left)
+	if index := strings.IndexByte(operandPart, '%'); index != -1 {
+		modulo, err := strconv.Atoi(strings.TrimSpace(operandPart[index+1:]))
+		if err != nil || modulo <= 0 {
+			return PluralRelation{}, errors.Errorf("invalid modulo in relation '%s'", relation)
+		}
+		result.Modulo = modulo
+		operandPart = strings.TrimSpace(operandPart[:index])
+	}
+	if len(operandPart) != 1 || !PluralOperand(operandPart[0]).Valid() {
+		return PluralRelation{}, errors.Errorf("invalid operand '%s'", operandPart)
+	}
+	result.Operand = PluralOperand(operandPart[0])
+
+	for _, rangePart := range strings.Split(strings.TrimSpace(right), ",") {
+		bounds := strings.Split(strings.TrimSpace(rangePart), "..")
+		if len(bounds) > 2 {
+			return PluralRelation{}, errors.Errorf("invalid range '%s'", rangePart)
+		}
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return PluralRelation{}, errors.Errorf("invalid range '%s'", rangePart)
+		}
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				return PluralRelation{}, errors.Errorf("invalid range '%s'", rangePart)
+			}
+		}
+		result.Ranges = append(result.Ranges, PluralRange{From: from, To: to})
+	}
+	return result, nil
+}
+
+// Match reports whether the relation holds for the given operands.
+func (r PluralRelation) Match(ops PluralOperands) bool {
+	value := ops.value(r.Operand)
+	if r.Modulo != 0 {
+		value = math.Mod(value, float64(r.Modulo))
+	}
+	return r.matchValue(value) != r.Negated
+}
+
+func (r PluralRelation) matchValue(value float64) bool {
+	if value != math.Trunc(value) {
+		return false
+	}
+	for _, rng := range r.Ranges {
+		if value >= float64(rng.From) && value <= float64(rng.To) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c PluralCondition) Match(ops PluralOperands) bool {
+	for _, group := range c {
+		matched := true
+		for _, relation := range group {
+			if !relation.Match(ops) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// IntegerOnly returns a simplified condition assuming that the number is always an integer.
+// Relations on fraction operands are evaluated in advance, so that the returned condition
+// only contains relations on the operands n and i.
+func (c PluralCondition) IntegerOnly() PluralCondition {
+	result := PluralCondition{}
+	for _, group := range c {
+		simplified := []PluralRelation{}
+		alwaysFalse := false
+		for _, relation := range group {
+			if relation.Operand.IsInteger() {
+				simplified = append(simplified, relation)
+			} else if !relation.Match(PluralOperands{}) {
+				alwaysFalse = true
+				break
+			}
+		}
+		if alwaysFalse {
+			continue
+		}
+		if len(simplified) == 0 {
+			return PluralCondition{{}}
+		}
+		result = append(result, simplified)
+	}
+	return result
+}
+
+// IntegerExpression returns the condition as a C-style boolean expression on an integer variable,
+// such as `value % 10 == 1 && value % 100 != 11`. The expression is also valid in Go, Kotlin and Swift.
+// The condition should be simplified with IntegerOnly beforehand, and should not be empty.
+func (c PluralCondition) IntegerExpression(variable string) string {
+	groups := make([]string, 0, len(c))
+	for _, group := range c {
+		relations := make([]string, 0, len(group))
+		for _, relation := range group {
+			relations = append(relations, relation.integerExpression(variable))
+		}
+		groups = append(groups, strings.Join(relations, " && "))
+	}
+	return strings.Join(groups, " || ")
+}
+
+func (r PluralRelation) integerExpression(variable string) string {
+	operand := variable
+	if r.Modulo != 0 {
+		operand = variable + " % " + strconv.Itoa(r.Modulo)
+	}
+
+	alternatives := make([]string, 0, len(r.Ranges))
+	for _, rng := range r.Ranges {
+		if rng.From == rng.To {
+			alternatives = append(alternatives, operand+" == "+strconv.Itoa(rng.From))
+		} else {
+			alternatives = append(alternatives, operand+" >= "+strconv.Itoa(rng.From)+" && "+operand+" <= "+strconv.Itoa(rng.To))
+		}
+	}
+	if len(r.Ranges) == 1 && r.Ranges[0].From == r.Ranges[0].To {
+		if r.Negated {
+			return operand + " != " + strconv.Itoa(r.Ranges[0].From)
+		}
+		return alternatives[0]
+	}
+	if r.Negated {
+		return "!(" + strings.Join(alternatives, " || ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " || ") + ")"
+}
+
+// SelectPluralCategory returns the category of the first matching rule, or "other" if no rule matches.
+func SelectPluralCategory(rules []PluralRule, ops PluralOperands) PluralCategory {
+	for _, rule := range rules {
+		if rule.Condition.Match(ops) {
+			return rule.Category
+		}
+	}
+	return OtherPluralCategory
+}
@@ -0,0 +1,47 @@
+package dictionary
+
+import "fmt"
+
+// SourcePosition is a position in a source file. Line and Column start from 1,
+// and are 0 if unknown.
+type SourcePosition struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (s SourcePosition) String() string {
+	if s.Line == 0 {
+		return s.File
+	}
+	if s.File == "" {
+		return fmt.Sprintf("%d:%d", s.Line, s.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", s.File, s.Line, s.Column)
+}
+
+// EntryPosition is the position of an entry in a source file.
+type EntryPosition struct {
+	// Key is the position of the entry key.
+	Key SourcePosition
+	// Fields are the positions of the field names, such as languages.
+	Fields map[string]SourcePosition
+	// Values are the positions of the field values.
+	Values map[string]SourcePosition
+}
+
+// ContentPositions are the positions of entries in source files, which are recorded by importers.
+type ContentPositions map[EntryKey]EntryPosition
+
+// Of returns the position of a field of an entry, or the position of the entry
+// if the field is empty or its position is unknown.
+func (c ContentPositions) Of(key EntryKey, field string) (SourcePosition, bool) {
+	entry, ok := c[key]
+	if !ok {
+		return SourcePosition{}, false
+	}
+	if position, ok := entry.Fields[field]; ok && field != "" {
+		return position, true
+	}
+	return entry.Key, true
+}
@@ -0,0 +1,131 @@
+package dictionary
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LanguageStats is the translation coverage of a language in a content.
+type LanguageStats struct {
+	Language string
+	Required bool
+	// TotalKeys is the number of entries, and TranslatedKeys is the number of entries with a text of the language.
+	TotalKeys      int
+	TranslatedKeys int
+	// Words and Characters are counted in the texts of the language without templates.
+	// Characters of Chinese, Japanese and Thai are counted as words, as they are not separated by spaces.
+	Words      int
+	Characters int
+}
+
+// MissingKeys returns the number of entries without a text of the language.
+func (l LanguageStats) MissingKeys() int {
+	return l.TotalKeys - l.TranslatedKeys
+}
+
+// Coverage returns the percentage of entries with a text of the language. It is 100 if there are no entries.
+func (l LanguageStats) Coverage() float64 {
+	if l.TotalKeys == 0 {
+		return 100
+	}
+	return float64(l.TranslatedKeys) * 100 / float64(l.TotalKeys)
+}
+
+// SubtreeStats is the translation coverage of a top-level subtree of a content.
+// Name is the top-level key of the subtree, and is empty for entries at the top level.
+type SubtreeStats struct {
+	Name      string
+	Languages []LanguageStats
+}
+
+// ContentStats is the translation coverage of the supported languages in a content,
+// in the order of the supported languages.
+type ContentStats struct {
+	Languages []LanguageStats
+	Subtrees  []SubtreeStats
+}
+
+// MissingOptionalTranslations returns the number of texts missing in languages which are not required.
+func (c ContentStats) MissingOptionalTranslations() int {
+	count := 0
+	for _, stats := range c.Languages {
+		if !stats.Required {
+			count += stats.MissingKeys()
+		}
+	}
+	return count
+}
+
+// Language returns the coverage of a language, or false if the language is not supported.
+func (c ContentStats) Language(lang string) (LanguageStats, bool) {
+	for _, stats := range c.Languages {
+		if stats.Language == lang {
+			return stats, true
+		}
+	}
+	return LanguageStats{}, false
+}
+
+// ComputeContentStats counts the texts of the supported languages in the content,
+// and in each of the top-level subtrees of the content sorted by name.
+func ComputeContentStats(content ContentRepresentation, metadata Metadata) ContentStats {
+	stats := ContentStats{
+		Languages: computeLanguageStats(content.ToFlattened(), metadata),
+		Subtrees:  []SubtreeStats{},
+	}
+
+	tree := content.ToTree()
+	names := make([]string, 0, len(tree.Children))
+	for name := range tree.Children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(tree.Entries) > 0 {
+		topLevel := FlattenedContent{}
+		for key, entry := range tree.Entries {
+			topLevel[EntryKey(key)] = entry
+		}
+		stats.Subtrees = append(stats.Subtrees, SubtreeStats{Languages: computeLanguageStats(&topLevel, metadata)})
+	}
+	for _, name := range names {
+		stats.Subtrees = append(stats.Subtrees, SubtreeStats{
+			Name:      name,
+			Languages: computeLanguageStats(tree.Children[name].ToFlattened(), metadata),
+		})
+	}
+	return stats
+}
+
+func computeLanguageStats(flattened *FlattenedContent, metadata Metadata) []LanguageStats {
+	requiredLangSet := metadata.RequiredLanguageSet()
+	languages := make([]LanguageStats, 0, len(metadata.SupportedLanguages))
+	for _, lang := range metadata.SupportedLanguages {
+		_, required := requiredLangSet[lang]
+		stats := LanguageStats{Language: lang, Required: required, TotalKeys: len(*flattened)}
+		for _, entry := range *flattened {
+			text, ok := entry[lang]
+			if !ok {
+				continue
+			}
+			stripped := TemplateStrippedText(text)
+			stats.TranslatedKeys++
+			stats.Words += countWords(stripped)
+			stats.Characters += utf8.RuneCountInString(stripped)
+		}
+		languages = append(languages, stats)
+	}
+	return languages
+}
+
+// countWords counts the words of a text in the same way texts are split to be compared by DiffTexts.
+func countWords(text string) int {
+	count := 0
+	for _, token := range diffTokens(text) {
+		r, _ := utf8.DecodeRuneInString(token)
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			count++
+		}
+	}
+	return count
+}
@@ -15,6 +15,12 @@ import (
 // 3. precision
 const NumericOptionRegex = `([0,+]*)([1-9]+\d*)?(?:\.(\d+))?`
 
+// Regular expression for matching a plural choice with a category name, such as `few:items`.
+// Capturing groups are
+// 1. category
+// 2. choice
+var pluralChoiceRegex = regexp.MustCompile(`^\s*([a-z]+|\d+):(.*)$`)
+
 type TemplateKeyType string
 
 const (
@@ -52,14 +58,66 @@ func (n NumericTemplateFormatOption) IsZero() bool {
 	return !n.WidthSet && !n.PrecisionSet && !n.CommaSeparator && !n.AlwaysAddSign
 }
 
+// PluralTemplateFormatOption is the option of a plural template.
+// Choices are either keyed by plural category (`one:item,other:items`),
+// or listed in the order of the language's plural categories (`item,items`).
+type PluralTemplateFormatOption struct {
+	// Named maps category names to choices. Nil if the choices are positional.
+	Named map[PluralCategory]string
+	// Positional is the list of choices written without category names.
+	Positional []string
+}
+
+// ChoicesFor returns the choices keyed by category,
+// checking them against the plural categories of a language.
+func (p PluralTemplateFormatOption) ChoicesFor(categories []PluralCategory) (map[PluralCategory]string, error) {
+	if p.Named == nil {
+		if len(p.Positional) != len(categories) {
+			return nil, errors.Errorf("expected %d plural choices, got %d", len(categories), len(p.Positional))
+		}
+		choices := make(map[PluralCategory]string, len(categories))
+		for index, category := range categories {
+			choices[category] = p.Positional[index]
+		}
+		return choices, nil
+	}
+
+	categorySet := make(map[PluralCategory]struct{}, len(categories))
+	for _, category := range categories {
+		categorySet[category] = struct{}{}
+	}
+	for category := range p.Named {
+		if _, ok := categorySet[category]; !ok {
+			return nil, errors.Errorf("unknown plural category '%s'", category)
+		}
+	}
+	if _, ok := p.Named[OtherPluralCategory]; !ok {
+		return nil, errors.Errorf("plural category '%s' is missing", OtherPluralCategory)
+	}
+	return p.Named, nil
+}
+
 type BoolTemplateFormatOption struct {
 	UseLocaleValues bool
 	TrueValue       string
 	FalseValue      string
 }
 
+// Translatable reports whether the template has text to be translated, which are the choices of
+// plural templates and of bool templates with their own values.
+func (t TemplateKeyFormat) Translatable() bool {
+	switch t.Kind {
+	case PluralTemplateKeyType:
+		return true
+	case BoolTemplateKeyType:
+		return !t.Option.(BoolTemplateFormatOption).UseLocaleValues
+	default:
+		return false
+	}
+}
+
 func (t TemplateKeyFormat) Compatible(other TemplateKeyFormat) bool {
-	return t.Kind == other.Kind
+	return keyTypesCompatible(t.Kind, other.Kind)
 }
 
 func ParseTemplateKeyFormat(kind, option string) (TemplateKeyFormat, error) {
@@ -83,9 +141,36 @@ func ParseTemplateKeyFormat(kind, option string) (TemplateKeyFormat, error) {
 }
 
 func parsePluralFormat(option string) (TemplateKeyFormat, error) {
+	splitOptions := strings.Split(option, ",")
+	named := map[PluralCategory]string{}
+	unnamed := []string{}
+	for _, choice := range splitOptions {
+		match := pluralChoiceRegex.FindStringSubmatch(choice)
+		if match == nil {
+			unnamed = append(unnamed, choice)
+			continue
+		}
+		if _, exists := named[PluralCategory(match[1])]; exists {
+			return TemplateKeyFormat{}, errors.Errorf("duplicate plural category '%s'", match[1])
+		}
+		named[PluralCategory(match[1])] = match[2]
+	}
+	// Choices are either all named or all positional. A choice without a category among named choices
+	// is most likely a typo, which would otherwise show the categories of the other choices as text.
+	if len(unnamed) == len(splitOptions) {
+		return TemplateKeyFormat{
+			Kind:   PluralTemplateKeyType,
+			Option: PluralTemplateFormatOption{Positional: splitOptions},
+		}, nil
+	}
+	if len(unnamed) > 0 {
+		return TemplateKeyFormat{}, errors.Errorf(
+			"plural choice '%s' has no category, while other choices have categories", strings.TrimSpace(unnamed[0]),
+		)
+	}
 	return TemplateKeyFormat{
 		Kind:   PluralTemplateKeyType,
-		Option: strings.Split(option, ","),
+		Option: PluralTemplateFormatOption{Named: named},
 	}, nil
 }
 
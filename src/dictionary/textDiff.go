@@ -0,0 +1,113 @@
+package dictionary
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TextDiffOp is the operation of a segment of a text difference.
+type TextDiffOp string
+
+const (
+	TextDiffEqual  TextDiffOp = "equal"
+	TextDiffInsert TextDiffOp = "insert"
+	TextDiffDelete TextDiffOp = "delete"
+)
+
+// TextDiffSegment is a part of a text difference.
+type TextDiffSegment struct {
+	Op   TextDiffOp
+	Text string
+}
+
+// DiffTexts returns the word-level difference from old to new.
+// Texts are split into words, runs of spaces and punctuation characters. Characters of scripts
+// which are written without spaces, such as Han and Kana, are compared one by one.
+func DiffTexts(old, new string) []TextDiffSegment {
+	oldTokens, newTokens := diffTokens(old), diffTokens(new)
+
+	// lengths[i][j] is the length of the longest common subsequence of oldTokens[i:] and newTokens[j:].
+	lengths := make([][]int, len(oldTokens)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newTokens)+1)
+	}
+	for i := len(oldTokens) - 1; i >= 0; i-- {
+		for j := len(newTokens) - 1; j >= 0; j-- {
+			if oldTokens[i] == newTokens[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	segments := []TextDiffSegment{}
+	add := func(op TextDiffOp, text string) {
+		if last := len(segments) - 1; last >= 0 && segments[last].Op == op {
+			segments[last].Text += text
+			return
+		}
+		segments = append(segments, TextDiffSegment{Op: op, Text: text})
+	}
+	i, j := 0, 0
+	for i < len(oldTokens) && j < len(newTokens) {
+		switch {
+		case oldTokens[i] == newTokens[j]:
+			add(TextDiffEqual, oldTokens[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			add(TextDiffDelete, oldTokens[i])
+			i++
+		default:
+			add(TextDiffInsert, newTokens[j])
+			j++
+		}
+	}
+	for ; i < len(oldTokens); i++ {
+		add(TextDiffDelete, oldTokens[i])
+	}
+	for ; j < len(newTokens); j++ {
+		add(TextDiffInsert, newTokens[j])
+	}
+	return segments
+}
+
+func diffTokens(text string) []string {
+	tokens := []string{}
+	current := strings.Builder{}
+	currentKind := 0
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range text {
+		kind := diffTokenKind(r)
+		if kind == 0 || kind != currentKind {
+			flush()
+		}
+		current.WriteRune(r)
+		currentKind = kind
+	}
+	flush()
+	return tokens
+}
+
+// diffTokenKind returns the kind of token a character belongs to, or 0 for characters which are tokens by themselves.
+func diffTokenKind(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai):
+		return 0
+	case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsMark(r):
+		return 1
+	case unicode.IsSpace(r):
+		return 2
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,203 @@
+package dictionary
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TranslationState is the state of the translation of a text in the translation workflow.
+type TranslationState string
+
+const (
+	// DraftState is the state of texts written but not finished by translators.
+	DraftState TranslationState = "draft"
+	// MachineTranslatedState is the state of texts translated by a machine translation provider,
+	// which need to be reviewed.
+	MachineTranslatedState TranslationState = "machine-translated"
+	// ReviewedState is the state of texts checked by a reviewer.
+	ReviewedState TranslationState = "reviewed"
+	// ApprovedState is the state of texts approved to be released.
+	ApprovedState TranslationState = "approved"
+)
+
+// TranslationStates are the valid translation states, in the order of the workflow.
+var TranslationStates = []TranslationState{DraftState, MachineTranslatedState, ReviewedState, ApprovedState}
+
+func (t TranslationState) Valid() bool {
+	for _, state := range TranslationStates {
+		if t == state {
+			return true
+		}
+	}
+	return false
+}
+
+// TranslationStatus is the translation state of a language of an entry, with who changed it and when.
+// Author and UpdatedAt are optional.
+//
+// Statuses are written to the translation state field of the entry as `lang:state|author|time`,
+// separated by `;`, such as `ko:reviewed|alice|2022-07-01T09:00:00Z; ja:machine-translated`.
+type TranslationStatus struct {
+	State     TranslationState
+	Author    string
+	UpdatedAt time.Time
+}
+
+func (t TranslationStatus) String() string {
+	text := string(t.State)
+	if t.Author != "" || !t.UpdatedAt.IsZero() {
+		text += "|" + t.Author
+	}
+	if !t.UpdatedAt.IsZero() {
+		text += "|" + t.UpdatedAt.Format(time.RFC3339)
+	}
+	return text
+}
+
+func parseTranslationStatus(text string) (TranslationStatus, error) {
+	parts := strings.Split(text, "|")
+	if len(parts) > 3 {
+		return TranslationStatus{}, errors.Errorf("invalid translation status '%s': expected 'state|author|time'", text)
+	}
+	status := TranslationStatus{State: TranslationState(strings.TrimSpace(parts[0]))}
+	if !status.State.Valid() {
+		return TranslationStatus{}, errors.Errorf("unknown translation state '%s'", status.State)
+	}
+	if len(parts) > 1 {
+		status.Author = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		updatedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(parts[2]))
+		if err != nil {
+			return TranslationStatus{}, errors.Errorf("invalid time '%s' of translation status: expected RFC 3339", strings.TrimSpace(parts[2]))
+		}
+		status.UpdatedAt = updatedAt
+	}
+	return status, nil
+}
+
+// TranslationStatuses parses the translation statuses of the entry, keyed by language.
+// Languages without a status are not included.
+func (e Entry) TranslationStatuses() (map[string]TranslationStatus, error) {
+	items, err := e.translationStatusItems()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]TranslationStatus, len(items))
+	for lang, item := range items {
+		status, err := parseTranslationStatus(item)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid translation state of '%s'", lang)
+		}
+		statuses[lang] = status
+	}
+	return statuses, nil
+}
+
+// translationStatusItems splits the translation state field into the unparsed statuses of languages.
+func (e Entry) translationStatusItems() (map[string]string, error) {
+	items := map[string]string{}
+	for _, item := range strings.Split(e[TranslationStateField], ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		lang, status, found := strings.Cut(item, ":")
+		lang, status = strings.TrimSpace(lang), strings.TrimSpace(status)
+		if !found || lang == "" || status == "" {
+			return nil, errors.Errorf("invalid translation state '%s': expected 'language:state'", strings.TrimSpace(item))
+		}
+		if _, exists := items[lang]; exists {
+			return nil, errors.Errorf("duplicate translation state of '%s'", lang)
+		}
+		items[lang] = status
+	}
+	return items, nil
+}
+
+// setTranslationStatusItems writes the unparsed statuses of languages to the translation state field,
+// sorted by language. The field is removed if there are no statuses.
+func (e Entry) setTranslationStatusItems(items map[string]string) {
+	if len(items) == 0 {
+		delete(e, TranslationStateField)
+		return
+	}
+	joined := make([]string, 0, len(items))
+	for _, lang := range sortedLanguageKeys(items) {
+		joined = append(joined, lang+":"+items[lang])
+	}
+	e[TranslationStateField] = strings.Join(joined, "; ")
+}
+
+// SetTranslationStatus sets the translation status of a language. A status without a state removes the status of the language.
+// Invalid statuses of other languages are dropped.
+func (e Entry) SetTranslationStatus(lang string, status TranslationStatus) error {
+	if status.State != "" && !status.State.Valid() {
+		return errors.Errorf("unknown translation state '%s'", status.State)
+	}
+	if strings.ContainsAny(status.Author, "|;") {
+		return errors.Errorf("author '%s' should not contain '|' or ';'", status.Author)
+	}
+
+	items, err := e.translationStatusItems()
+	if err != nil {
+		items = map[string]string{}
+	}
+	if status.State == "" {
+		delete(items, lang)
+	} else {
+		items[lang] = status.String()
+	}
+	e.setTranslationStatusItems(items)
+	return nil
+}
+
+// mergeTranslationStatuses merges the translation state fields language by language.
+// Conflicting is false if the field could be merged, and otherwise ours and theirs are the merged field
+// taking the status of each side for the languages changed differently on both sides.
+// Fields which cannot be parsed are not merged.
+func mergeTranslationStatuses(base, ours, theirs Entry) (oursValue, theirsValue MergeValue, conflicting, ok bool) {
+	baseItems, baseErr := base.translationStatusItems()
+	oursItems, oursErr := ours.translationStatusItems()
+	theirsItems, theirsErr := theirs.translationStatusItems()
+	if baseErr != nil || oursErr != nil || theirsErr != nil {
+		return MergeValue{}, MergeValue{}, false, false
+	}
+
+	langSet := map[string]struct{}{}
+	for _, items := range []map[string]string{baseItems, oursItems, theirsItems} {
+		for lang := range items {
+			langSet[lang] = struct{}{}
+		}
+	}
+	oursMerged, theirsMerged := Entry{}, Entry{}
+	oursMergedItems, theirsMergedItems := map[string]string{}, map[string]string{}
+	for lang := range langSet {
+		baseItem, inBase := baseItems[lang]
+		oursItem, inOurs := oursItems[lang]
+		theirsItem, inTheirs := theirsItems[lang]
+		baseLang := MergeValue{Text: baseItem, Exists: inBase}
+		oursLang := MergeValue{Text: oursItem, Exists: inOurs}
+		theirsLang := MergeValue{Text: theirsItem, Exists: inTheirs}
+
+		oursLangMerged, theirsLangMerged := oursLang, oursLang
+		switch {
+		case oursLang == theirsLang, theirsLang == baseLang:
+		case oursLang == baseLang:
+			oursLangMerged, theirsLangMerged = theirsLang, theirsLang
+		default:
+			theirsLangMerged = theirsLang
+			conflicting = true
+		}
+		if oursLangMerged.Exists {
+			oursMergedItems[lang] = oursLangMerged.Text
+		}
+		if theirsLangMerged.Exists {
+			theirsMergedItems[lang] = theirsLangMerged.Text
+		}
+	}
+	oursMerged.setTranslationStatusItems(oursMergedItems)
+	theirsMerged.setTranslationStatusItems(theirsMergedItems)
+	return fieldValue(oursMerged, TranslationStateField), fieldValue(theirsMerged, TranslationStateField), conflicting, true
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/pkg/errors"
 )
 
 type ContentNode struct {
@@ -46,26 +45,11 @@ func (c *ContentNode) toFlattenedWalk(flattened *FlattenedContent) {
 }
 
 func (c *ContentNode) Validate(metadata Metadata, options ContentValidationOptions) *multierror.Error {
-	validator := NewContentValidator(metadata, options)
-	return c.validateWalk(&validator)
+	return c.ToFlattened().Validate(metadata, options)
 }
 
-func (c *ContentNode) validateWalk(validator *ContentValidator) (err *multierror.Error) {
-	for key, entry := range c.Entries {
-		entryKey := c.Key.NewChild(key)
-		if keyErr := ValidateJoinedKey(entryKey); keyErr != nil {
-			err = multierror.Append(err, errors.Wrapf(keyErr, "invalid content '%s'", key))
-		}
-		if _, entryErr := validator.Validate(entry); entryErr != nil {
-			err = multierror.Append(err, errors.Wrapf(entryErr, "invalid content '%s'", key))
-		}
-	}
-	for _, child := range c.Children {
-		if childErr := child.validateWalk(validator); childErr != nil {
-			err = multierror.Append(err, childErr)
-		}
-	}
-	return
+func (c *ContentNode) Diagnose(metadata Metadata, options ContentValidationOptions) Diagnostics {
+	return c.ToFlattened().Diagnose(metadata, options)
 }
 
 func (c *ContentNode) Print() {
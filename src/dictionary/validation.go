@@ -3,6 +3,7 @@ package dictionary
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -22,6 +23,9 @@ type ContentValidator struct {
 
 type ContentValidationOptions struct {
 	SkipLangSupportCheck bool
+	// StrictTemplateKeys requires every language of an entry to use the same set of template keys,
+	// except for the keys listed in the omitted templates of the entry.
+	StrictTemplateKeys bool
 }
 
 func NewContentValidator(m Metadata, options ContentValidationOptions) ContentValidator {
@@ -42,53 +46,206 @@ func NewContentValidator(m Metadata, options ContentValidationOptions) ContentVa
 	return validator
 }
 
-func (c ContentValidator) Validate(entry Entry) (templateKeys map[string]TemplateKeyFormat, err error) {
-	templateKeys = map[string]TemplateKeyFormat{}
+// Validate validates an entry, and returns the template keys of the entry with their formats.
+// The error is the first error found in the entry.
+func (c ContentValidator) Validate(entry Entry) (map[string]TemplateKeyFormat, error) {
+	templateKeys, diagnostics := c.Diagnose("", entry)
+	return templateKeys, diagnostics.FirstError()
+}
+
+// Diagnose validates an entry with the given key, and returns all problems found with the template keys of the entry.
+// Unsupported languages are reported as warnings if SkipLangSupportCheck is set.
+func (c ContentValidator) Diagnose(entryKey EntryKey, entry Entry) (map[string]TemplateKeyFormat, Diagnostics) {
+	templateKeys := map[string]TemplateKeyFormat{}
 	templateKeyOwner := map[string]string{}
+	langKeySets := map[string]map[string]TemplateKeyFormat{}
+	diagnostics := Diagnostics{}
+	report := func(code DiagnosticCode, severity Severity, lang, templateKey, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Code:        code,
+			Severity:    severity,
+			Message:     message,
+			Key:         entryKey,
+			Language:    lang,
+			TemplateKey: templateKey,
+		})
+	}
 
 	if !c.options.SkipLangSupportCheck {
-		for requiredLang := range c.requiredLangSet {
+		for _, requiredLang := range c.metadata.RequiredLanguages {
 			if _, ok := entry[requiredLang]; !ok {
-				err = errors.Errorf("'%s' is required but does not exist", requiredLang)
-				return
+				report(CodeMissingRequiredLanguage, SeverityError, requiredLang, "", fmt.Sprintf("'%s' is required but does not exist", requiredLang))
 			}
 		}
 	}
-	for key := range entry {
-		if keyErr := ValidateJoinedKey(EntryKey(key)); keyErr != nil {
-			err = keyErr
-			return
-		}
+	for _, key := range sortedLanguageKeys(entry) {
+		// Supported languages may have tags other than snake_case, such as `en-XA`.
 		_, isSupportedLang := c.supportedLangSet[key]
-		if !(isSupportedLang || key == "context") {
+		if keyErr := ValidateJoinedKey(EntryKey(key)); keyErr != nil && !isSupportedLang {
+			report(CodeInvalidField, SeverityError, key, "", keyErr.Error())
+			continue
+		}
+		if !(isSupportedLang || IsEntryMetaField(key)) {
 			if c.options.SkipLangSupportCheck {
-				fmt.Printf("unsupported language '%s'\n", key)
+				report(CodeUnsupportedLanguage, SeverityWarning, key, "", fmt.Sprintf("unsupported language '%s'", key))
 			} else {
-				err = errors.Errorf("language '%s' is not in supported languages", key)
-				return
+				report(CodeUnsupportedLanguage, SeverityError, key, "", fmt.Sprintf("language '%s' is not in supported languages", key))
+				continue
 			}
 		}
+		if HasConflictMarkers(entry[key]) {
+			report(CodeMergeConflict, SeverityError, key, "", "unresolved merge conflict")
+			continue
+		}
+		if key == OmittedTemplatesField || key == TranslationStateField {
+			continue
+		}
 		langTemplateKeys, contentErr := entry.TemplateKeys(key)
 		if contentErr != nil {
-			err = errors.Wrapf(contentErr, "invalid template for '%s'", key)
-			return
+			report(CodeInvalidTemplate, SeverityError, key, "", errors.Wrapf(contentErr, "invalid template for '%s'", key).Error())
+			continue
+		}
+		if key != ContextField {
+			langKeySets[key] = langTemplateKeys
+			if templateKey, pluralErr := c.validatePluralTemplates(entry, key); pluralErr != nil {
+				report(CodeInvalidPluralTemplate, SeverityError, key, templateKey, errors.Wrapf(pluralErr, "invalid template for '%s'", key).Error())
+				continue
+			}
 		}
-		for templateKey, format := range langTemplateKeys {
+		for _, templateKey := range sortedLanguageKeys(langTemplateKeys) {
+			format := langTemplateKeys[templateKey]
 			if existingFormat, exists := templateKeys[templateKey]; exists {
 				if !format.Compatible(existingFormat) {
-					err = errors.Errorf(
+					report(CodeIncompatibleTemplateTypes, SeverityError, key, templateKey, fmt.Sprintf(
 						"incompatible constraints in key '%s': '%s' from %s vs. '%s' from %s",
-						templateKey, existingFormat, templateKeyOwner[templateKey], format, key,
-					)
+						templateKey, existingFormat.Kind, templateKeyOwner[templateKey], format.Kind, key,
+					))
 				}
-				return
 			} else {
 				templateKeys[templateKey] = format
 				templateKeyOwner[templateKey] = key
 			}
 		}
 	}
-	return
+	diagnostics = append(diagnostics, c.validateTranslationStatuses(entryKey, entry)...)
+	if c.options.StrictTemplateKeys {
+		for _, diagnostic := range c.validateTemplateKeySets(entry, langKeySets) {
+			diagnostic.Key = entryKey
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return templateKeys, diagnostics
+}
+
+// validateTemplateKeySets checks that every language uses the template keys of the required languages.
+// If the entry has none of the required languages, the keys of all languages are used instead.
+// Keys omitted intentionally should be listed in the omitted templates of the entry.
+func (c ContentValidator) validateTemplateKeySets(entry Entry, langKeySets map[string]map[string]TemplateKeyFormat) Diagnostics {
+	mismatch := func(code DiagnosticCode, lang, templateKey, message string) Diagnostic {
+		return Diagnostic{Code: code, Severity: SeverityError, Message: message, Language: lang, TemplateKey: templateKey}
+	}
+	omitted, err := entry.OmittedTemplateKeys()
+	if err != nil {
+		return Diagnostics{mismatch(CodeInvalidOmittedTemplates, "", "", err.Error())}
+	}
+	expected := map[string]struct{}{}
+	for lang := range c.requiredLangSet {
+		for key := range langKeySets[lang] {
+			expected[key] = struct{}{}
+		}
+	}
+	if len(expected) == 0 {
+		for _, keys := range langKeySets {
+			for key := range keys {
+				expected[key] = struct{}{}
+			}
+		}
+	}
+
+	diagnostics := Diagnostics{}
+	for _, lang := range sortedLanguageKeys(langKeySets) {
+		keys := langKeySets[lang]
+		for _, key := range sortedLanguageKeys(expected) {
+			if _, ok := keys[key]; ok {
+				continue
+			}
+			if _, ok := omitted[lang][key]; !ok {
+				diagnostics = append(diagnostics, mismatch(CodeTemplateKeyMismatch, lang, key, fmt.Sprintf("'%s' is missing template key %s", lang, key)))
+			}
+		}
+		for _, key := range sortedLanguageKeys(keys) {
+			if _, ok := expected[key]; !ok {
+				diagnostics = append(diagnostics, mismatch(CodeTemplateKeyMismatch, lang, key, fmt.Sprintf("'%s' has template key %s not in other languages", lang, key)))
+			}
+		}
+	}
+	for _, lang := range sortedLanguageKeys(omitted) {
+		if _, ok := langKeySets[lang]; !ok {
+			diagnostics = append(diagnostics, mismatch(CodeInvalidOmittedTemplates, lang, "", fmt.Sprintf("omitted templates are listed for '%s', which has no text", lang)))
+			continue
+		}
+		for _, key := range sortedLanguageKeys(omitted[lang]) {
+			if _, ok := langKeySets[lang][key]; ok {
+				diagnostics = append(diagnostics, mismatch(CodeInvalidOmittedTemplates, lang, key, fmt.Sprintf("template key '%s' is listed as omitted but used in '%s'", key, lang)))
+			} else if _, ok := expected[key]; !ok {
+				diagnostics = append(diagnostics, mismatch(CodeInvalidOmittedTemplates, lang, key, fmt.Sprintf("template key '%s' is listed as omitted for '%s' but not used in the entry", key, lang)))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// validateTranslationStatuses checks that the translation statuses can be parsed,
+// and that every language with a status has a text in the entry.
+func (c ContentValidator) validateTranslationStatuses(entryKey EntryKey, entry Entry) Diagnostics {
+	invalid := func(lang, message string) Diagnostic {
+		return Diagnostic{Code: CodeInvalidTranslationState, Severity: SeverityError, Message: message, Key: entryKey, Language: lang}
+	}
+	if HasConflictMarkers(entry[TranslationStateField]) {
+		return Diagnostics{}
+	}
+	statuses, err := entry.TranslationStatuses()
+	if err != nil {
+		return Diagnostics{invalid("", err.Error())}
+	}
+
+	diagnostics := Diagnostics{}
+	for _, lang := range sortedLanguageKeys(statuses) {
+		if _, ok := c.supportedLangSet[lang]; !ok && !c.options.SkipLangSupportCheck {
+			diagnostics = append(diagnostics, invalid(lang, fmt.Sprintf("translation state is set for '%s', which is not in supported languages", lang)))
+		} else if _, ok := entry[lang]; !ok {
+			diagnostics = append(diagnostics, invalid(lang, fmt.Sprintf("translation state is set for '%s', which has no text", lang)))
+		}
+	}
+	return diagnostics
+}
+
+func sortedLanguageKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validatePluralTemplates checks the choices of every plural template in a language
+// against the plural categories of the language.
+// The template key of the invalid template is returned with the error.
+func (c ContentValidator) validatePluralTemplates(entry Entry, lang string) (string, error) {
+	categories := c.metadata.PluralCategories(lang)
+	invalidKey := ""
+	_, err := entry.ReplacedTemplateValue(lang, func(templateKey string, format TemplateKeyFormat) (string, error) {
+		if format.Kind != PluralTemplateKeyType {
+			return "", nil
+		}
+		if _, choiceErr := format.Option.(PluralTemplateFormatOption).ChoicesFor(categories); choiceErr != nil {
+			invalidKey = templateKey
+			return "", errors.Wrapf(choiceErr, "invalid plural template '%s'", templateKey)
+		}
+		return "", nil
+	})
+	return invalidKey, err
 }
 
 func ValidateJoinedKey(key EntryKey) error {
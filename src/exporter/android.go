@@ -0,0 +1,194 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+// AndroidDictionaryExporter is a DictionaryProjectExporter generating Android string resources.
+//
+// A `values-<lang>/strings.xml` file is written for each language, and the first required language
+// is also written to `values/strings.xml` as the default resource. Entries with plural templates
+// are written as `<plurals>`. Templates are converted to positional format specifiers (ie. `%1$d`),
+// numbered in the order they appear in the first required language.
+//
+// The project root is usually the `res` folder of an Android module.
+// Existing files other than the generated strings.xml files are left untouched.
+type AndroidDictionaryExporter struct{}
+
+var androidEscaper = strings.NewReplacer(
+	`\`, `\\`, `'`, `\'`, `"`, `\"`, "\n", `\n`, "\t", `\t`,
+	"&", "&amp;", "<", "&lt;", ">", "&gt;",
+)
+
+func (a AndroidDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	flattened := content.ToFlattened()
+	keys := make([]string, 0, len(*flattened))
+	for key := range *flattened {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	entries := make(map[string]*printfEntry, len(keys))
+	keysByName := map[string]string{}
+	for _, key := range keys {
+		name := androidResourceName(dictionary.EntryKey(key))
+		if existing, ok := keysByName[name]; ok {
+			return errors.Errorf("keys '%s' and '%s' have the same resource name '%s'", existing, key, name)
+		}
+		keysByName[name] = key
+
+		entry, err := newPrintfEntry((*flattened)[dictionary.EntryKey(key)], metadata)
+		if err != nil {
+			return errors.Wrapf(err, "invalid entry '%s'", key)
+		}
+		entries[key] = entry
+	}
+
+	now := time.Now()
+	for _, lang := range metadata.SupportedLanguages {
+		folders := []string{}
+		qualifier, err := androidLanguageQualifier(lang)
+		if err != nil {
+			return err
+		}
+		folders = append(folders, "values-"+qualifier)
+		if len(metadata.RequiredLanguages) > 0 && lang == metadata.RequiredLanguages[0] {
+			folders = append(folders, "values")
+		}
+
+		resources, err := a.buildResources(lang, keys, entries, now)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build resources for language '%s'", lang)
+		}
+		for _, folder := range folders {
+			if err := os.MkdirAll(path.Join(projectRoot, folder), os.ModePerm); err != nil {
+				return errors.Wrapf(err, "failed to create folder '%s'", folder)
+			}
+			if err := os.WriteFile(path.Join(projectRoot, folder, "strings.xml"), []byte(resources), os.ModePerm); err != nil {
+				return errors.Wrapf(err, "failed to write '%s'", path.Join(folder, "strings.xml"))
+			}
+		}
+	}
+	return nil
+}
+
+func (a AndroidDictionaryExporter) buildResources(lang string, keys []string, entries map[string]*printfEntry, now time.Time) (string, error) {
+	builder := strings.Builder{}
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	builder.WriteString(fmt.Sprintf("<!-- Generated with donggu at %s. DO NOT EDIT. -->\n", now.UTC().Format(time.RFC3339)))
+	builder.WriteString("<resources>\n")
+
+	for _, key := range keys {
+		entry := entries[key]
+		if _, ok := entry.entry[lang]; !ok {
+			continue
+		}
+		name := androidResourceName(dictionary.EntryKey(key))
+
+		comments := []string{}
+		if context := entry.entry["context"]; context != "" {
+			comments = append(comments, context)
+		}
+		if entry.HasArguments() {
+			comments = append(comments, "Arguments: "+entry.ArgumentDescription(lang))
+		}
+		if len(comments) > 0 {
+			// '--' is not allowed in XML comments.
+			comment := strings.ReplaceAll(strings.Join(comments, " / "), "--", "- -")
+			builder.WriteString(fmt.Sprintf("    <!-- %s -->\n", comment))
+		}
+
+		if entry.pluralKey == "" {
+			value, err := entry.Format(lang, dictionary.OtherPluralCategory, javaPrintfDialect)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid entry '%s'", key)
+			}
+			formatted := ""
+			if !entry.HasArguments() && strings.Contains(value, "%") {
+				formatted = ` formatted="false"`
+			}
+			builder.WriteString(fmt.Sprintf("    <string name=\"%s\"%s>%s</string>\n", name, formatted, androidEscape(value)))
+			continue
+		}
+
+		// Texts without plural templates are the same for every quantity.
+		categories := []dictionary.PluralCategory{dictionary.OtherPluralCategory}
+		if entry.HasPlural(lang) {
+			var err error
+			if categories, err = entry.PluralCategories(lang); err != nil {
+				return "", errors.Wrapf(err, "invalid entry '%s'", key)
+			}
+		}
+		builder.WriteString(fmt.Sprintf("    <plurals name=\"%s\">\n", name))
+		for _, category := range categories {
+			value, err := entry.Format(lang, category, javaPrintfDialect)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid entry '%s'", key)
+			}
+			builder.WriteString(fmt.Sprintf("        <item quantity=\"%s\">%s</item>\n", category, androidEscape(value)))
+		}
+		builder.WriteString("    </plurals>\n")
+	}
+	builder.WriteString("</resources>\n")
+	return builder.String(), nil
+}
+
+// androidResourceName converts an entry key to a resource name, joining key parts with underscores.
+func androidResourceName(key dictionary.EntryKey) string {
+	return strings.Join(key.Parts(), "_")
+}
+
+// androidEscape escapes a text to be used as the content of a string resource.
+// Texts with leading, trailing or repeated spaces are quoted, as whitespace is collapsed otherwise.
+func androidEscape(text string) string {
+	escaped := androidEscaper.Replace(text)
+	if strings.HasPrefix(escaped, "@") || strings.HasPrefix(escaped, "?") {
+		escaped = `\` + escaped
+	}
+	if strings.HasPrefix(text, " ") || strings.HasSuffix(text, " ") || strings.Contains(text, "  ") {
+		escaped = `"` + escaped + `"`
+	}
+	return escaped
+}
+
+// androidLanguageQualifier converts a language tag to an Android resource qualifier,
+// such as 'en', 'pt-rBR' or 'b+zh+Hant'.
+func androidLanguageQualifier(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid language '%s'", lang)
+	}
+	base, _ := tag.Base()
+	script, scriptConfidence := tag.Script()
+	region, regionConfidence := tag.Region()
+
+	if scriptConfidence == language.Exact {
+		parts := []string{"b", base.String(), script.String()}
+		if regionConfidence == language.Exact {
+			parts = append(parts, region.String())
+		}
+		return strings.Join(parts, "+"), nil
+	}
+	if regionConfidence == language.Exact {
+		return fmt.Sprintf("%s-r%s", base, region), nil
+	}
+	return base.String(), nil
+}
+
+func (a AndroidDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return nil
+}
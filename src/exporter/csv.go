@@ -23,12 +23,22 @@ func (c CsvDictionaryExporter) ExportContent(
 	encoder := csv.NewWriter(file)
 	defer encoder.Flush()
 
-	locales := make([]string, 0, len(metadata.SupportedLanguages))
-	locales = append(locales, metadata.SupportedLanguages...)
+	columns := make([]string, 0, len(metadata.SupportedLanguages))
+	columns = append(columns, metadata.SupportedLanguages...)
+	// Meta fields such as translation states are written after the languages if any entry has them,
+	// so that they are kept when the file is imported again.
+	for _, field := range []string{dictionary.ContextField, dictionary.OmittedTemplatesField, dictionary.TranslationStateField} {
+		for _, value := range *flattened {
+			if _, ok := value[field]; ok {
+				columns = append(columns, field)
+				break
+			}
+		}
+	}
 
-	header := make([]string, 0, len(locales)+2)
+	header := make([]string, 0, len(columns)+2)
 	header = append(header, "index", "key")
-	header = append(header, locales...)
+	header = append(header, columns...)
 	if err := encoder.Write(header); err != nil {
 		return errors.Wrap(err, "error while writing file")
 	}
@@ -36,11 +46,11 @@ func (c CsvDictionaryExporter) ExportContent(
 	index := 0
 	for key, value := range *flattened {
 		index += 1
-		row := make([]string, 0, len(locales)+2)
+		row := make([]string, 0, len(columns)+2)
 		row = append(row, fmt.Sprintf("%d", index), string(key))
-		for _, locale := range locales {
-			if localeValue, ok := value[locale]; ok {
-				row = append(row, localeValue)
+		for _, column := range columns {
+			if columnValue, ok := value[column]; ok {
+				row = append(row, columnValue)
 			} else {
 				row = append(row, "")
 			}
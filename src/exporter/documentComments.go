@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// documentComments are the comments of a YAML or TOML document, so that they can be restored
+// after the document is rewritten. Comments are kept with their comment markers.
+type documentComments struct {
+	Head string
+	Foot string
+	// Keys are the comments of keys, by the dotted path of the key such as `screens.title.en`.
+	// Flat and nested content have the same paths.
+	Keys map[string]keyComments
+}
+
+type keyComments struct {
+	Head string
+	Line string
+	Foot string
+}
+
+func newDocumentComments() documentComments {
+	return documentComments{Keys: map[string]keyComments{}}
+}
+
+// metadataValue returns metadata in the shape of metadata.json as decoded JSON values,
+// with integers kept as integers.
+func metadataValue(metadata dictionary.Metadata) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(JsonDictionaryExporter{}.buildMetadataObject(metadata))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode metadata")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to decode metadata")
+	}
+	return normalizeJsonNumbers(decoded).(map[string]interface{}), nil
+}
+
+func normalizeJsonNumbers(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, member := range value {
+			value[key] = normalizeJsonNumbers(member)
+		}
+		return value
+	case []interface{}:
+		for index, item := range value {
+			value[index] = normalizeJsonNumbers(item)
+		}
+		return value
+	case json.Number:
+		if integer, err := value.Int64(); err == nil {
+			return integer
+		}
+		float, _ := value.Float64()
+		return float
+	default:
+		return value
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/dave/jennifer/jen"
 	"github.com/maasasia/donggu/code"
 	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
 )
 
 type golangArgumentFormatter struct {
@@ -29,29 +30,37 @@ func (g golangArgumentFormatter) ArgumentType(key string, argType dictionary.Tem
 	return
 }
 
-func (g golangArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (formatString string, arg jen.Code) {
+func (g golangArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (formatString string, arg jen.Code, err error) {
 	key = code.TemplateKeyToCamelCase(key)
 	switch format.Kind {
 	case dictionary.IntTemplateKeyType:
-		return g.formatNumeric(key, format) + "d", jen.Id(key)
+		return g.formatNumeric(key, format) + "d", jen.Id(key), nil
 	case dictionary.FloatTemplateKeyType:
-		return g.formatNumeric(key, format) + "f", jen.Id(key)
+		return g.formatNumeric(key, format) + "f", jen.Id(key), nil
 	case dictionary.BoolTemplateKeyType:
-		return g.formatBool(key, format)
+		formatString, arg = g.formatBool(key, format)
+		return formatString, arg, nil
 	case dictionary.PluralTemplateKeyType:
-		return "%s", g.formatPlural(language, key, format)
+		arg, err = g.formatPlural(language, key, format)
+		return "%s", arg, err
 	default:
-		return "%s", jen.Id(key)
+		return "%s", jen.Id(key), nil
 	}
 }
 
-func (g golangArgumentFormatter) formatPlural(language, key string, format dictionary.TemplateKeyFormat) jen.Code {
-	choiceStrs := format.Option.([]string)
-	choices := make([]jen.Code, 0, len(choiceStrs))
-	for _, choice := range choiceStrs {
-		choices = append(choices, jen.Lit(choice))
+func (g golangArgumentFormatter) formatPlural(language, key string, format dictionary.TemplateKeyFormat) (jen.Code, error) {
+	choices, err := pluralChoices(format, language, g.metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid plural choices")
+	}
+	choiceDict := jen.Dict{}
+	for category, choice := range choices {
+		choiceDict[jen.Lit(category)] = jen.Lit(choice)
 	}
-	return jen.Id(pluralSelectorFnName(language)).Call(jen.Id(key), jen.Index().String().Values(choices...))
+	return jen.Id("selectPluralChoice").Call(
+		jen.Id(pluralSelectorFnName(language)).Call(jen.Id(key)),
+		jen.Map(jen.String()).String().Values(choiceDict),
+	), nil
 }
 
 func (g golangArgumentFormatter) formatBool(key string, format dictionary.TemplateKeyFormat) (formatString string, arg jen.Code) {
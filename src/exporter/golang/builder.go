@@ -127,11 +127,11 @@ func (g *GolangBuilder) addEntry(entry dictionary.Entry, entryKey dictionary.Ent
 	g.builder.writeEntryType(entryKey, paramArgs)
 	g.builder.writeEntryMethod(entryKey, paramArgs, callArgs)
 	for lang := range entry {
-		if lang == "context" {
+		if dictionary.IsEntryMetaField(lang) {
 			continue
 		}
 		formatterValue, formatErr := g.buildFormatterReturnValue(entry, lang, templateKeys)
-		if err != nil {
+		if formatErr != nil {
 			err = errors.Wrap(formatErr, "failed to build formatter value")
 			return
 		}
@@ -170,7 +170,10 @@ func (g *GolangBuilder) buildFormatterReturnValue(
 ) (*jen.Statement, error) {
 	params := make([]jen.Code, 1)
 	templateString, err := entry.ReplacedTemplateValue(lang, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
-		formatString, argValue := golangArgumentFormatter{}.Format(lang, key, format)
+		formatString, argValue, formatErr := golangArgumentFormatter{metadata: g.metadata}.Format(lang, key, format)
+		if formatErr != nil {
+			return "", errors.Wrapf(formatErr, "failed to format template '%s'", key)
+		}
 		params = append(params, argValue)
 		return formatString, nil
 	})
@@ -179,6 +179,9 @@ func (g *golangCodeBuilder) writeEntryImpl(key dictionary.EntryKey, locale strin
 }
 
 func (g *golangCodeBuilder) writePluralSelectorImpl(language string, metadata *dictionary.Metadata) *jen.Statement {
+	if rules, ok := metadata.PluralRules(language); ok {
+		return g.writeCldrPluralSelectorImpl(language, rules)
+	}
 	defs, defsOk := metadata.Plurals[language]
 	if !defsOk {
 		defs = dictionary.DefaultPluralDefinition()
@@ -187,20 +190,107 @@ func (g *golangCodeBuilder) writePluralSelectorImpl(language string, metadata *d
 
 	defCode := []jen.Code{}
 	for index, def := range defs {
+		category := string(def.CategoryName(index))
 		if def.HasOperand {
 			block := jen.If(jen.Id("value").Op(def.Op).Lit(def.Operand).Op("==").Lit(def.Equals)).Block(
-				jen.Return(jen.Id("choices").Index(jen.Lit(index))),
+				jen.Return(jen.Lit(category)),
 			)
 			defCode = append(defCode, block)
 		} else {
 			block := jen.If(jen.Id("value").Op(def.Op).Lit(def.Equals)).Block(
-				jen.Return(jen.Id("choices").Index(jen.Lit(index))),
+				jen.Return(jen.Lit(category)),
 			)
 			defCode = append(defCode, block)
 		}
 	}
-	defCode = append(defCode, jen.Return(jen.Id("choices").Index(jen.Lit(len(defs)))))
+	defCode = append(defCode, jen.Return(jen.Lit(string(dictionary.OtherPluralCategory))))
+
+	fnBody := jen.Func().Id(fnName).Params(jen.Id("value").Int()).String().Block(defCode...)
+	return fnBody
+}
+
+// writeCldrPluralSelectorImpl writes a plural selector from CLDR plural rules.
+// Plural values are always integers in Go, so relations on fraction operands are evaluated in advance.
+func (g *golangCodeBuilder) writeCldrPluralSelectorImpl(language string, rules []dictionary.PluralRule) *jen.Statement {
+	fnName := pluralSelectorFnName(language)
+
+	defCode := []jen.Code{
+		jen.If(jen.Id("value").Op("<").Lit(0)).Block(jen.Id("value").Op("=").Op("-").Id("value")),
+	}
+	alwaysMatched := false
+	for _, rule := range rules {
+		condition := rule.Condition.IntegerOnly()
+		if len(condition) == 0 {
+			continue
+		}
+		if len(condition[0]) == 0 {
+			defCode = append(defCode, jen.Return(jen.Lit(string(rule.Category))))
+			alwaysMatched = true
+			break
+		}
+		block := jen.If(pluralConditionCode(condition)).Block(
+			jen.Return(jen.Lit(string(rule.Category))),
+		)
+		defCode = append(defCode, block)
+	}
+	if !alwaysMatched {
+		defCode = append(defCode, jen.Return(jen.Lit(string(dictionary.OtherPluralCategory))))
+	}
 
-	fnBody := jen.Func().Id(fnName).Params(jen.Id("value").Int(), jen.Id("choices").Index().String()).String().Block(defCode...)
+	fnBody := jen.Func().Id(fnName).Params(jen.Id("value").Int()).String().Block(defCode...)
 	return fnBody
 }
+
+func pluralConditionCode(condition dictionary.PluralCondition) *jen.Statement {
+	stmt := jen.Empty()
+	for groupIndex, group := range condition {
+		if groupIndex > 0 {
+			stmt.Op("||")
+		}
+		groupStmt := jen.Empty()
+		for relationIndex, relation := range group {
+			if relationIndex > 0 {
+				groupStmt.Op("&&")
+			}
+			groupStmt.Add(pluralRelationCode(relation))
+		}
+		if len(condition) > 1 && len(group) > 1 {
+			stmt.Parens(groupStmt)
+		} else {
+			stmt.Add(groupStmt)
+		}
+	}
+	return stmt
+}
+
+func pluralRelationCode(relation dictionary.PluralRelation) *jen.Statement {
+	operand := func() *jen.Statement {
+		if relation.Modulo != 0 {
+			return jen.Id("value").Op("%").Lit(relation.Modulo)
+		}
+		return jen.Id("value")
+	}
+
+	if len(relation.Ranges) == 1 && relation.Ranges[0].From == relation.Ranges[0].To {
+		if relation.Negated {
+			return operand().Op("!=").Lit(relation.Ranges[0].From)
+		}
+		return operand().Op("==").Lit(relation.Ranges[0].From)
+	}
+
+	alternatives := jen.Empty()
+	for index, rng := range relation.Ranges {
+		if index > 0 {
+			alternatives.Op("||")
+		}
+		if rng.From == rng.To {
+			alternatives.Add(operand().Op("==").Lit(rng.From))
+		} else {
+			alternatives.Add(operand().Op(">=").Lit(rng.From).Op("&&").Add(operand()).Op("<=").Lit(rng.To))
+		}
+	}
+	if relation.Negated {
+		return jen.Op("!").Parens(alternatives)
+	}
+	return jen.Parens(alternatives)
+}
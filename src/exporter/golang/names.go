@@ -24,7 +24,7 @@ func entryFormatTypeName(key dictionary.EntryKey) string {
 }
 
 func entryFormatFnName(key dictionary.EntryKey, locale string) string {
-	return "d_" + key.PascalCase() + "_Fmt_" + code.ToPascalCase(locale)
+	return "d_" + key.PascalCase() + "_Fmt_" + code.ToPascalCase(strings.ReplaceAll(locale, "-", "_"))
 }
 
 func pluralSelectorFnName(language string) string {
@@ -0,0 +1,155 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// i18nextCountKey is the name of the value which i18next selects plural forms by.
+const i18nextCountKey = "count"
+
+// I18nextDictionaryExporter writes messages for i18next (https://www.i18next.com).
+//
+// Templates are converted to interpolations (`{{NAME}}`), and numbers to the built-in number format.
+// Entries with plural templates are written as keys with a suffix for each plural category of the language
+// (`key_one`, `key_other`), and the key of the plural template is renamed to `count`.
+//
+// ExportContent writes the messages of all languages keyed by language, and Export writes a file for each language.
+//
+// Options:
+//   - nested: write messages nested by the parts of entry keys. Defaults to true.
+//     Entries under an entry with a text, such as `greet.sub` under `greet`, are written with their flat keys at the top level.
+type I18nextDictionaryExporter struct{}
+
+func (i I18nextDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessageFiles(projectRoot, content, metadata, libraryNestedOption(options, true), i.formatMessages)
+}
+
+func (i I18nextDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessages(file, content, metadata, libraryNestedOption(options, true), i.formatMessages)
+}
+
+func (i I18nextDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (i I18nextDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return validateNestedOption(options)
+}
+
+func (i I18nextDictionaryExporter) formatMessages(entry dictionary.Entry, lang string, metadata dictionary.Metadata) ([]libraryMessage, error) {
+	if strings.Contains(dictionary.TemplateStrippedText(entry[lang]), "{{") {
+		return nil, errors.New("'{{' cannot be written in i18next messages")
+	}
+	pluralKey, err := libraryPluralKey(entry[lang])
+	if err != nil {
+		return nil, err
+	}
+	if pluralKey == "" {
+		text, err := i.formatText(entry[lang], "")
+		if err != nil {
+			return nil, err
+		}
+		return []libraryMessage{{Text: text}}, nil
+	}
+
+	categories := metadata.PluralCategories(lang)
+	forms, err := poPluralForms(entry[lang], lang, metadata)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]libraryMessage, len(forms))
+	for index, form := range forms {
+		if !isCldrPluralCategory(categories[index]) {
+			return nil, errors.Errorf("plural category '%s' has no i18next equivalent", categories[index])
+		}
+		text, err := i.formatText(form, pluralKey)
+		if err != nil {
+			return nil, err
+		}
+		messages[index] = libraryMessage{Suffix: "_" + string(categories[index]), Text: text}
+	}
+	return messages, nil
+}
+
+// formatText converts the templates of a text. Templates with pluralKey are renamed to i18nextCountKey.
+func (i I18nextDictionaryExporter) formatText(text string, pluralKey string) (string, error) {
+	entry := dictionary.Entry{"": text}
+	return entry.ReplacedTemplateValue("", func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		if key == pluralKey {
+			key = i18nextCountKey
+		}
+		switch format.Kind {
+		case dictionary.IntTemplateKeyType, dictionary.FloatTemplateKeyType:
+			numberOptions, err := i.numberOptions(format)
+			if err != nil {
+				return "", errors.Wrapf(err, "cannot convert template '%s'", key)
+			}
+			return fmt.Sprintf("{{%s, number(%s)}}", key, strings.Join(numberOptions, "; ")), nil
+		case dictionary.BoolTemplateKeyType:
+			return "", errors.Errorf("cannot convert template '%s': bool templates have no i18next equivalent", key)
+		default:
+			return fmt.Sprintf("{{%s}}", key), nil
+		}
+	})
+}
+
+// numberOptions returns the options of the number format, which are the options of Intl.NumberFormat.
+func (i I18nextDictionaryExporter) numberOptions(format dictionary.TemplateKeyFormat) ([]string, error) {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	numberOptions := []string{}
+
+	isInteger := format.Kind == dictionary.IntTemplateKeyType
+	if isInteger {
+		numberOptions = append(numberOptions, "maximumFractionDigits: 0")
+	} else if option.PrecisionSet {
+		numberOptions = append(numberOptions,
+			fmt.Sprintf("minimumFractionDigits: %d", option.Precision),
+			fmt.Sprintf("maximumFractionDigits: %d", option.Precision),
+		)
+	}
+	numberOptions = append(numberOptions, fmt.Sprintf("useGrouping: %t", option.CommaSeparator))
+	if option.AlwaysAddSign {
+		numberOptions = append(numberOptions, "signDisplay: always")
+	}
+	if option.WidthSet {
+		// Only zero padding of the integer part can be expressed with Intl.NumberFormat.
+		if option.PadCharacter != "0" || option.AlwaysAddSign || option.CommaSeparator {
+			return nil, errors.New("width without zero padding has no i18next equivalent")
+		}
+		integerDigits := option.Width
+		if !isInteger {
+			if !option.PrecisionSet {
+				return nil, errors.New("width of floats without precision has no i18next equivalent")
+			}
+			integerDigits -= option.Precision + 1
+		}
+		if integerDigits > 0 {
+			numberOptions = append(numberOptions, fmt.Sprintf("minimumIntegerDigits: %d", integerDigits))
+		}
+	}
+	return numberOptions, nil
+}
+
+func isCldrPluralCategory(category dictionary.PluralCategory) bool {
+	for _, cldrCategory := range dictionary.PluralCategoryOrder {
+		if category == cldrCategory {
+			return true
+		}
+	}
+	return false
+}
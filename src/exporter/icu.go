@@ -0,0 +1,56 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/icu"
+	"github.com/pkg/errors"
+)
+
+// IcuDictionaryExporter is a DictionaryFileExporter.
+// It writes content in the same shape as content.json, with templates converted to ICU MessageFormat.
+type IcuDictionaryExporter struct{}
+
+func (i IcuDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	_ OptionMap,
+) error {
+	flattened := content.ToFlattened()
+
+	converted := map[dictionary.EntryKey]map[string]string{}
+	for key, entry := range *flattened {
+		convertedEntry := map[string]string{}
+		for lang, value := range entry {
+			if dictionary.IsEntryMetaField(lang) {
+				convertedEntry[lang] = value
+				continue
+			}
+			message, err := icu.FormatMessage(entry, lang, metadata)
+			if err != nil {
+				return errors.Wrapf(err, "failed to convert '%s' (%s)", key, lang)
+			}
+			convertedEntry[lang] = message
+		}
+		converted[key] = convertedEntry
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(converted); err != nil {
+		return errors.Wrap(err, "failed to encode JSON")
+	}
+	return nil
+}
+
+func (i IcuDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (i IcuDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return nil
+}
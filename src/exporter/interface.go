@@ -21,3 +21,9 @@ type DictionaryProjectExporter interface {
 	ValidateOptions(options OptionMap) error
 	Export(projectRoot string, content dictionary.ContentRepresentation, metadata dictionary.Metadata, options OptionMap) error
 }
+
+// ContentFileRewriter writes content to an existing content file, in the form of the file.
+// Formats which can be written either flat or nested keep the form of the file, and formats with comments keep them.
+type ContentFileRewriter interface {
+	RewriteContentFile(filePath string, content dictionary.ContentRepresentation) error
+}
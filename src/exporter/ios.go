@@ -0,0 +1,176 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// IosDictionaryExporter is a DictionaryProjectExporter generating iOS string resources.
+//
+// `<lang>.lproj/Localizable.strings` and `<lang>.lproj/Localizable.stringsdict` files are written
+// for each language. Texts with plural templates are written to the stringsdict file as plural rules,
+// with a variable for each plural template.
+// Templates are converted to positional format specifiers (ie. `%1$ld`),
+// numbered in the order they appear in the first required language.
+//
+// Existing files other than the generated files are left untouched.
+type IosDictionaryExporter struct{}
+
+var iosStringsEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+var iosPlistEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (i IosDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	flattened := content.ToFlattened()
+	keys := make([]string, 0, len(*flattened))
+	for key := range *flattened {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	entries := make(map[string]*printfEntry, len(keys))
+	for _, key := range keys {
+		entry, err := newPrintfEntry((*flattened)[dictionary.EntryKey(key)], metadata)
+		if err != nil {
+			return errors.Wrapf(err, "invalid entry '%s'", key)
+		}
+		entries[key] = entry
+	}
+
+	now := time.Now()
+	for _, lang := range metadata.SupportedLanguages {
+		folder := path.Join(projectRoot, lang+".lproj")
+		if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+			return errors.Wrapf(err, "failed to create folder for language '%s'", lang)
+		}
+
+		stringsFile, stringsDictFile, err := i.buildResources(lang, keys, entries, now)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build resources for language '%s'", lang)
+		}
+		if err := os.WriteFile(path.Join(folder, "Localizable.strings"), []byte(stringsFile), os.ModePerm); err != nil {
+			return errors.Wrapf(err, "failed to write strings file for language '%s'", lang)
+		}
+		if err := os.WriteFile(path.Join(folder, "Localizable.stringsdict"), []byte(stringsDictFile), os.ModePerm); err != nil {
+			return errors.Wrapf(err, "failed to write stringsdict file for language '%s'", lang)
+		}
+	}
+	return nil
+}
+
+func (i IosDictionaryExporter) buildResources(
+	lang string,
+	keys []string,
+	entries map[string]*printfEntry,
+	now time.Time,
+) (stringsFile string, stringsDictFile string, err error) {
+	stringsBuilder := strings.Builder{}
+	stringsBuilder.WriteString(fmt.Sprintf("/* Generated with donggu at %s. DO NOT EDIT. */\n", now.UTC().Format(time.RFC3339)))
+
+	dictBuilder := strings.Builder{}
+	dictBuilder.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	dictBuilder.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	dictBuilder.WriteString(fmt.Sprintf("<!-- Generated with donggu at %s. DO NOT EDIT. -->\n", now.UTC().Format(time.RFC3339)))
+	dictBuilder.WriteString("<plist version=\"1.0\">\n<dict>\n")
+
+	for _, key := range keys {
+		entry := entries[key]
+		if _, ok := entry.entry[lang]; !ok {
+			continue
+		}
+
+		if !entry.HasPlural(lang) {
+			value, formatErr := entry.Format(lang, dictionary.OtherPluralCategory, cocoaPrintfDialect)
+			if formatErr != nil {
+				err = errors.Wrapf(formatErr, "invalid entry '%s'", key)
+				return
+			}
+			comments := []string{}
+			if context := entry.entry["context"]; context != "" {
+				comments = append(comments, context)
+			}
+			if entry.HasArguments() {
+				comments = append(comments, "Arguments: "+entry.ArgumentDescription(lang))
+			}
+			stringsBuilder.WriteString("\n")
+			if len(comments) > 0 {
+				comment := strings.ReplaceAll(strings.Join(comments, " / "), "*/", "* /")
+				stringsBuilder.WriteString(fmt.Sprintf("/* %s */\n", comment))
+			}
+			stringsBuilder.WriteString(fmt.Sprintf("\"%s\" = \"%s\";\n", iosStringsEscaper.Replace(key), iosStringsEscaper.Replace(value)))
+			continue
+		}
+
+		categories, categoryErr := entry.PluralCategories(lang)
+		if categoryErr != nil {
+			err = errors.Wrapf(categoryErr, "invalid entry '%s'", key)
+			return
+		}
+		// Each plural template is a variable of its own, as the templates of a key may have different choices.
+		// Variables are numbered as `N_1`, `N_2` if the key has more than one plural template.
+		pluralCount := 0
+		for _, match := range templateOptionRegex.FindAllStringSubmatch(entry.entry[lang], -1) {
+			if match[2] == string(dictionary.PluralTemplateKeyType) {
+				pluralCount++
+			}
+		}
+		variables := []iosPluralVariable{}
+		format, formatErr := entry.FormatWith(lang, cocoaPrintfDialect, func(pluralKey string, choices map[dictionary.PluralCategory]string) string {
+			name := pluralKey
+			if pluralCount > 1 {
+				name = fmt.Sprintf("%s_%d", pluralKey, len(variables)+1)
+			}
+			variables = append(variables, iosPluralVariable{Name: name, Choices: choices})
+			return fmt.Sprintf("%%%d$#@%s@", entry.Position(pluralKey), name)
+		})
+		if formatErr != nil {
+			err = errors.Wrapf(formatErr, "invalid entry '%s'", key)
+			return
+		}
+
+		dictBuilder.WriteString(fmt.Sprintf("    <key>%s</key>\n    <dict>\n", iosPlistEscaper.Replace(key)))
+		dictBuilder.WriteString("        <key>NSStringLocalizedFormatKey</key>\n")
+		dictBuilder.WriteString(fmt.Sprintf("        <string>%s</string>\n", iosPlistEscaper.Replace(format)))
+		for _, variable := range variables {
+			dictBuilder.WriteString(fmt.Sprintf("        <key>%s</key>\n        <dict>\n", variable.Name))
+			dictBuilder.WriteString("            <key>NSStringFormatSpecTypeKey</key>\n")
+			dictBuilder.WriteString("            <string>NSStringPluralRuleType</string>\n")
+			dictBuilder.WriteString("            <key>NSStringFormatValueTypeKey</key>\n")
+			dictBuilder.WriteString(fmt.Sprintf("            <string>%s</string>\n", cocoaPrintfDialect.intVerb))
+			for _, category := range categories {
+				choice, ok := variable.Choices[category]
+				if !ok {
+					continue
+				}
+				dictBuilder.WriteString(fmt.Sprintf("            <key>%s</key>\n", category))
+				dictBuilder.WriteString(fmt.Sprintf("            <string>%s</string>\n", iosPlistEscaper.Replace(entry.escapeLiteral(choice))))
+			}
+			dictBuilder.WriteString("        </dict>\n")
+		}
+		dictBuilder.WriteString("    </dict>\n")
+	}
+
+	dictBuilder.WriteString("</dict>\n</plist>\n")
+	return stringsBuilder.String(), dictBuilder.String(), nil
+}
+
+// iosPluralVariable is a variable of a `.stringsdict` entry, selecting the choice of a plural template.
+type iosPluralVariable struct {
+	Name    string
+	Choices map[dictionary.PluralCategory]string
+}
+
+func (i IosDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return nil
+}
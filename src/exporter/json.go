@@ -12,11 +12,21 @@ import (
 )
 
 type jsonPluralDefinition struct {
-	Op    string `json:"op"`
-	Value int    `json:"value"`
+	Category string `json:"category,omitempty"`
+	Op       string `json:"op"`
+	Value    int    `json:"value"`
 }
 
+// cldrPluralValue is the plural definition value for using plural rules from CLDR data.
+const cldrPluralValue = "cldr"
+
 // JsonDictionaryExporter is a DictionaryExporter.
+//
+// Options:
+//   - nested: write content nested by the parts of entry keys, such as `{"screens": {"title": {...}}}`,
+//     instead of flat.
+//
+// When writing a project, content is written in the form of the existing content files unless 'nested' is given.
 type JsonDictionaryExporter struct{}
 
 func (j JsonDictionaryExporter) Export(
@@ -26,7 +36,6 @@ func (j JsonDictionaryExporter) Export(
 	options OptionMap,
 ) error {
 	metadataFilePath := path.Join(projectRoot, "metadata.json")
-	contentFilePath := path.Join(projectRoot, "content.json")
 
 	metadataFile, err := os.OpenFile(metadataFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
 	if err != nil {
@@ -34,16 +43,28 @@ func (j JsonDictionaryExporter) Export(
 	}
 	defer metadataFile.Close()
 
-	contentFile, err := os.OpenFile(contentFilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err := j.ExportMetadata(metadataFile, metadata, options); err != nil {
+		return errors.Wrapf(err, "failed to write metadata")
+	}
+
+	nested, ok := nestedOption(options)
+	if !ok {
+		nested = j.isNestedProject(projectRoot, metadata.ContentLayout)
+	}
+	if !metadata.ContentLayout.IsSingle() {
+		if err := j.exportLayoutContent(projectRoot, content, metadata.ContentLayout, nested); err != nil {
+			return errors.Wrapf(err, "failed to write content")
+		}
+		return nil
+	}
+
+	contentFile, err := os.OpenFile(path.Join(projectRoot, "content.json"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return errors.Wrapf(err, "failed to open content file")
 	}
 	defer contentFile.Close()
 
-	if err := j.ExportMetadata(metadataFile, metadata, options); err != nil {
-		return errors.Wrapf(err, "failed to write metadata")
-	}
-	if err := j.ExportContent(contentFile, content, metadata, options); err != nil {
+	if err := j.exportContent(contentFile, content, nested); err != nil {
 		return errors.Wrapf(err, "failed to write content")
 	}
 	return nil
@@ -53,13 +74,32 @@ func (j JsonDictionaryExporter) ExportContent(
 	file io.Writer,
 	content dictionary.ContentRepresentation,
 	_ dictionary.Metadata,
-	_ OptionMap,
+	options OptionMap,
 ) error {
-	flattened := content.ToFlattened()
+	nested, _ := nestedOption(options)
+	return j.exportContent(file, content, nested)
+}
+
+func (j JsonDictionaryExporter) RewriteContentFile(filePath string, content dictionary.ContentRepresentation) error {
+	nested := j.isNestedFile(filePath, false)
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return errors.Wrap(err, "failed to open content file")
+	}
+	defer file.Close()
+	return j.exportContent(file, content, nested)
+}
+
+func (j JsonDictionaryExporter) exportContent(file io.Writer, content dictionary.ContentRepresentation, nested bool) error {
+	var value interface{} = content.ToFlattened()
+	if nested {
+		value = buildNestedJsonContent(content.ToTree())
+	}
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(flattened); err != nil {
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
 		return errors.Wrap(err, "failed to encode JSON")
 	}
 	return nil
@@ -70,6 +110,14 @@ func (j JsonDictionaryExporter) ExportMetadata(file io.Writer, metadata dictiona
 	encoder.SetIndent("", "  ")
 	encoder.SetEscapeHTML(false)
 
+	if err := encoder.Encode(j.buildMetadataObject(metadata)); err != nil {
+		return errors.Wrap(err, "failed to encode JSON")
+	}
+	return nil
+}
+
+// buildMetadataObject returns metadata in the shape of metadata.json.
+func (j JsonDictionaryExporter) buildMetadataObject(metadata dictionary.Metadata) map[string]interface{} {
 	jsonObj := map[string]interface{}{
 		"version":             metadata.Version,
 		"required_languages":  metadata.RequiredLanguages,
@@ -77,31 +125,45 @@ func (j JsonDictionaryExporter) ExportMetadata(file io.Writer, metadata dictiona
 		"exporter_options":    metadata.ExporterOptions,
 		"plurals":             j.buildPluralObject(metadata),
 	}
-
-	if err := encoder.Encode(jsonObj); err != nil {
-		return errors.Wrap(err, "failed to encode JSON")
+	if !metadata.Lint.IsZero() {
+		jsonObj["lint"] = j.buildLintObject(metadata.Lint)
 	}
-	return nil
+	if !metadata.ContentLayout.IsZero() {
+		layout := map[string]interface{}{"type": dictionary.ContentLayoutSingle}
+		if metadata.ContentLayout.Type != "" {
+			layout["type"] = metadata.ContentLayout.Type
+		}
+		if metadata.ContentLayout.Directory != "" {
+			layout["directory"] = metadata.ContentLayout.Directory
+		}
+		jsonObj["content_layout"] = layout
+	}
+	return jsonObj
 }
 
 func (j JsonDictionaryExporter) ValidateOptions(options OptionMap) error {
-	return nil
+	return validateNestedOption(options)
 }
 
-func (j JsonDictionaryExporter) buildPluralObject(metadata dictionary.Metadata) map[string][]jsonPluralDefinition {
-	ret := map[string][]jsonPluralDefinition{}
+func (j JsonDictionaryExporter) buildPluralObject(metadata dictionary.Metadata) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for lang := range metadata.CldrPlurals {
+		ret[lang] = cldrPluralValue
+	}
 	for lang, defs := range metadata.Plurals {
 		conv := make([]jsonPluralDefinition, len(defs))
 		for index, def := range defs {
 			if def.HasOperand {
 				conv[index] = jsonPluralDefinition{
-					Op:    fmt.Sprintf("%s%d", def.Op, def.Operand),
-					Value: def.Equals,
+					Category: string(def.Category),
+					Op:       fmt.Sprintf("%s%d", def.Op, def.Operand),
+					Value:    def.Equals,
 				}
 			} else {
 				conv[index] = jsonPluralDefinition{
-					Op:    def.Op,
-					Value: def.Equals,
+					Category: string(def.Category),
+					Op:       def.Op,
+					Value:    def.Equals,
 				}
 			}
 		}
@@ -109,3 +171,22 @@ func (j JsonDictionaryExporter) buildPluralObject(metadata dictionary.Metadata)
 	}
 	return ret
 }
+
+func (j JsonDictionaryExporter) buildLintObject(lint dictionary.LintConfig) map[string]interface{} {
+	rules := map[string]interface{}{}
+	for name, rule := range lint.Rules {
+		conv := map[string]interface{}{}
+		for key, value := range rule.Options {
+			conv[key] = value
+		}
+		if rule.Severity != "" {
+			conv["severity"] = rule.Severity
+		}
+		rules[name] = conv
+	}
+	ret := map[string]interface{}{"rules": rules}
+	if lint.SourceLanguage != "" {
+		ret["source_language"] = lint.SourceLanguage
+	}
+	return ret
+}
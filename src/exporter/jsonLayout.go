@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// exportLayoutContent writes content to the files of a layout which is not dictionary.ContentLayoutSingle.
+// Other JSON files in the content directory are removed, so that deleted top-level keys or languages
+// are not imported again.
+func (j JsonDictionaryExporter) exportLayoutContent(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	layout dictionary.ContentLayout,
+	nested bool,
+) error {
+	files := map[string]interface{}{}
+	switch layout.Type {
+	case dictionary.ContentLayoutKey:
+		fileContents := map[string]dictionary.FlattenedContent{}
+		for key, entry := range *content.ToFlattened() {
+			name := key.TopLevel()
+			if _, ok := fileContents[name]; !ok {
+				fileContents[name] = dictionary.FlattenedContent{}
+			}
+			fileContents[name][key] = entry
+		}
+		for name, fileContent := range fileContents {
+			if nested {
+				files[name] = buildNestedJsonContent(fileContent.ToTree())
+			} else {
+				files[name] = fileContent
+			}
+		}
+	case dictionary.ContentLayoutLanguage:
+		fileTexts := map[string]map[dictionary.EntryKey]string{}
+		for key, entry := range *content.ToFlattened() {
+			for field, text := range entry {
+				if _, ok := fileTexts[field]; !ok {
+					fileTexts[field] = map[dictionary.EntryKey]string{}
+				}
+				fileTexts[field][key] = text
+			}
+		}
+		for name, texts := range fileTexts {
+			if nested {
+				files[name] = buildNestedJsonTexts(texts)
+			} else {
+				files[name] = texts
+			}
+		}
+	default:
+		return errors.Errorf("unknown layout type '%s'", layout.Type)
+	}
+
+	directory := filepath.Join(projectRoot, filepath.FromSlash(layout.ContentDirectory()))
+	if err := os.MkdirAll(directory, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create content directory")
+	}
+	for name, fileContent := range files {
+		if err := j.writeJsonFile(filepath.Join(directory, name+".json"), fileContent); err != nil {
+			return errors.Wrapf(err, "failed to write '%s'", layout.FilePath(name))
+		}
+	}
+
+	dirEntries, err := os.ReadDir(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to read content directory")
+	}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || path.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		name := strings.TrimSuffix(dirEntry.Name(), ".json")
+		if _, ok := files[name]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(directory, dirEntry.Name())); err != nil {
+			return errors.Wrapf(err, "failed to remove '%s'", layout.FilePath(name))
+		}
+	}
+	return nil
+}
+
+func (j JsonDictionaryExporter) writeJsonFile(filePath string, value interface{}) error {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return errors.Wrap(err, "failed to encode JSON")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// buildNestedJsonContent returns content in the nested form of content.json.
+func buildNestedJsonContent(node *dictionary.ContentNode) map[string]interface{} {
+	object := map[string]interface{}{}
+	for _, member := range nestedMembers(node) {
+		memberObject := map[string]interface{}{}
+		fieldsObject := memberObject
+		if member.SelfEntry {
+			fieldsObject = map[string]interface{}{}
+			memberObject[selfEntryName] = fieldsObject
+		}
+		for field, text := range member.Entry {
+			fieldsObject[field] = text
+		}
+		if member.Child != nil {
+			for name, value := range buildNestedJsonContent(member.Child) {
+				memberObject[name] = value
+			}
+		}
+		object[member.Name] = memberObject
+	}
+	return object
+}
+
+// buildNestedJsonTexts returns the texts of a field in the nested form, such as `{"screens": {"title": "Title"}}`.
+// The text of an entry which also has children is written under selfEntryName.
+func buildNestedJsonTexts(texts map[dictionary.EntryKey]string) map[string]interface{} {
+	root := map[string]interface{}{}
+	for key, text := range texts {
+		object := root
+		parts := key.Parts()
+		for _, part := range parts[:len(parts)-1] {
+			switch child := object[part].(type) {
+			case map[string]interface{}:
+				object = child
+			case string:
+				object[part] = map[string]interface{}{selfEntryName: child}
+				object = object[part].(map[string]interface{})
+			default:
+				object[part] = map[string]interface{}{}
+				object = object[part].(map[string]interface{})
+			}
+		}
+
+		name := parts[len(parts)-1]
+		if child, ok := object[name].(map[string]interface{}); ok {
+			child[selfEntryName] = text
+		} else {
+			object[name] = text
+		}
+	}
+	return root
+}
+
+// isNestedProject returns whether the existing content files of a project are written in the nested form.
+func (j JsonDictionaryExporter) isNestedProject(projectRoot string, layout dictionary.ContentLayout) bool {
+	if layout.IsSingle() {
+		return j.isNestedFile(path.Join(projectRoot, layout.Path()), false)
+	}
+
+	directory := filepath.Join(projectRoot, filepath.FromSlash(layout.ContentDirectory()))
+	dirEntries, err := os.ReadDir(directory)
+	if err != nil {
+		return false
+	}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || path.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		if j.isNestedFile(filepath.Join(directory, dirEntry.Name()), layout.Type == dictionary.ContentLayoutLanguage) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNestedFile returns whether an existing content file is written in the nested form.
+// Files of a single field are nested if they have an object.
+func (j JsonDictionaryExporter) isNestedFile(filePath string, fieldFile bool) bool {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return false
+	}
+	if !fieldFile {
+		return isNestedContentValue(decoded)
+	}
+	for _, value := range decoded {
+		if _, ok := value.(map[string]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package exporter
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter/kotlin"
+	"github.com/maasasia/donggu/util"
+	"github.com/pkg/errors"
+)
+
+// KotlinDictionaryExporter is a DictionaryProjectExporter
+// generating Kotlin sources for using the dictionary.
+//
+// The project root is a source folder for the Kotlin package given by the option 'packageName'.
+type KotlinDictionaryExporter struct{}
+
+var kotlinPackageNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+func (k KotlinDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	packageName := options["packageName"].(string)
+	if err := k.prepareProject(projectRoot, packageName); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+
+	builder := kotlin.NewKotlinBuilder(metadata, packageName)
+	if err := builder.Run(content.ToTree()); err != nil {
+		return err
+	}
+
+	return builder.Build(metadata, projectRoot)
+}
+
+func (k KotlinDictionaryExporter) prepareProject(projectRoot, packageName string) error {
+	if err := os.RemoveAll(projectRoot); err != nil {
+		return err
+	}
+	if err := code.CopyTemplateTo("kotlin", projectRoot, code.CopyTemplateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+	if err := os.Mkdir(path.Join(projectRoot, "generated"), fs.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create generated folder")
+	}
+
+	packageRenameErr := util.BatchReplaceFiles(
+		[]string{path.Join(projectRoot, "Donggu.kt")},
+		"donggu.template",
+		packageName,
+	)
+	if packageRenameErr != nil {
+		return errors.Wrap(packageRenameErr, "failed to write package names")
+	}
+	return nil
+}
+
+func (k KotlinDictionaryExporter) ValidateOptions(options OptionMap) error {
+	convOpts := map[string]interface{}(options)
+	if packageName, err := util.SafeAccessMap[string](&convOpts, "packageName"); err == nil {
+		if strings.TrimSpace(packageName) == "" {
+			return errors.New("package name (key 'packageName') should not be empty")
+		}
+		if !kotlinPackageNameRegex.MatchString(packageName) {
+			return errors.Errorf("package name (key 'packageName') '%s' is not a valid Kotlin package name", packageName)
+		}
+	} else {
+		return err
+	}
+	return nil
+}
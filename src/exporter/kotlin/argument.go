@@ -0,0 +1,109 @@
+package kotlin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var kotlinStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+)
+
+// stringLiteral returns text as a Kotlin string literal.
+func stringLiteral(text string) string {
+	return `"` + kotlinStringEscaper.Replace(text) + `"`
+}
+
+type kotlinArgumentFormatter struct {
+	metadata *dictionary.Metadata
+	argTypes map[string]dictionary.TemplateKeyFormat
+}
+
+func (k kotlinArgumentFormatter) ArgumentType(argType dictionary.TemplateKeyFormat) string {
+	switch argType.Kind {
+	case dictionary.IntTemplateKeyType:
+		return "Int"
+	case dictionary.FloatTemplateKeyType:
+		return "Double"
+	case dictionary.BoolTemplateKeyType:
+		return "Boolean"
+	case dictionary.PluralTemplateKeyType:
+		return "Int"
+	default:
+		return "String"
+	}
+}
+
+// Format returns a string template expression (ie. `${name}`) for a template.
+func (k kotlinArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
+	name := argumentName(key)
+	switch format.Kind {
+	case dictionary.IntTemplateKeyType:
+		return k.formatNumeric(name, "d", format), nil
+	case dictionary.FloatTemplateKeyType:
+		return k.formatNumeric(name, "f", format), nil
+	case dictionary.BoolTemplateKeyType:
+		return k.formatBool(name, format), nil
+	case dictionary.PluralTemplateKeyType:
+		return k.formatPlural(language, key, format)
+	default:
+		return "${" + name + "}", nil
+	}
+}
+
+func (k kotlinArgumentFormatter) formatPlural(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(k.metadata.PluralCategories(language))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid plural choices")
+	}
+	categories := make([]string, 0, len(choices))
+	for category := range choices {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	pairs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		pairs = append(pairs, fmt.Sprintf("%s to %s", stringLiteral(category), stringLiteral(choices[dictionary.PluralCategory(category)])))
+	}
+	return fmt.Sprintf("${selectPluralChoice(%s(%s), mapOf(%s))}", pluralSelectorFnName(language), argumentName(key), strings.Join(pairs, ", ")), nil
+}
+
+func (k kotlinArgumentFormatter) formatBool(name string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.BoolTemplateFormatOption)
+	if option.UseLocaleValues {
+		return "${" + name + "}"
+	}
+	return fmt.Sprintf("${printBooleanValue(%s, %s, %s)}", name, stringLiteral(option.TrueValue), stringLiteral(option.FalseValue))
+}
+
+func (k kotlinArgumentFormatter) formatNumeric(name, conversion string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	if option.IsZero() && conversion == "d" {
+		return "${" + name + "}"
+	}
+	formatString := "%"
+	if option.AlwaysAddSign {
+		formatString += "+"
+	}
+	if option.CommaSeparator {
+		formatString += ","
+	}
+	// java.util.Formatter does not accept zero padding without a width, nor a precision for integers.
+	if option.PadCharacter == "0" && option.WidthSet {
+		formatString += "0"
+	}
+	if option.WidthSet {
+		formatString += fmt.Sprintf("%d", option.Width)
+	}
+	if option.PrecisionSet && conversion == "f" {
+		formatString += fmt.Sprintf(".%d", option.Precision)
+	}
+	formatString += conversion
+	return fmt.Sprintf("${formatNumber(%s, %s)}", stringLiteral(formatString), name)
+}
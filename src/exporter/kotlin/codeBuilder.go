@@ -0,0 +1,249 @@
+package kotlin
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+type kotlinParameter struct {
+	name     string
+	typeName string
+}
+
+type kotlinCodeBuilder struct {
+	packageName string
+	nodeBuilder code.IndentedCodeBuilder
+	dataBuilder code.IndentedCodeBuilder
+	// memberBuilder holds the entry methods of the node being walked,
+	// until they are written with the node.
+	memberBuilder code.IndentedCodeBuilder
+}
+
+func newKotlinCodeBuilder(packageName string) *kotlinCodeBuilder {
+	return &kotlinCodeBuilder{packageName: packageName}
+}
+
+func (k *kotlinCodeBuilder) outputNodeFile(w io.Writer, now time.Time) error {
+	builder := k.fileBuilder(now)
+	builder.AppendBlock(k.nodeBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (k *kotlinCodeBuilder) outputDataFile(w io.Writer, now time.Time) error {
+	builder := k.fileBuilder(now)
+	builder.AppendBlock(k.dataBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (k *kotlinCodeBuilder) outputLanguageFile(w io.Writer, metadata dictionary.Metadata, now time.Time) error {
+	builder := k.fileBuilder(now)
+
+	requiredLangs := metadata.RequiredLanguageSet()
+	builder.AppendLines(fmt.Sprintf("const val VERSION = %s", stringLiteral(metadata.Version)), "")
+	builder.AppendLines("internal val LANGUAGES: Map<String, Boolean> = mapOf(")
+	builder.Indent()
+	for _, language := range metadata.SupportedLanguages {
+		_, required := requiredLangs[language]
+		builder.AppendLines(fmt.Sprintf("%s to %t,", stringLiteral(language), required))
+	}
+	builder.Unindent()
+	builder.AppendLines(")")
+
+	for _, lang := range metadata.SupportedLanguages {
+		builder.AppendLines("")
+		builder.AppendBlock(k.writePluralSelectorImpl(lang, &metadata))
+	}
+	builder.Build(w)
+	return nil
+}
+
+func (k *kotlinCodeBuilder) fileBuilder(now time.Time) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(
+		fmt.Sprintf("// Generated with donggu at %s", now.UTC().Format(time.RFC3339)),
+		"// AUTOGENERATED CODE. DO NOT EDIT.",
+		`@file:Suppress("ClassName", "ObjectPropertyName", "FunctionName")`,
+		"",
+		"package "+k.packageName,
+		"",
+	)
+	return builder
+}
+
+// writeNode writes the class of a node with its child properties,
+// and the entry methods written to memberBuilder since the last node.
+// Members of the root node are written as extensions of the Donggu class.
+func (k *kotlinCodeBuilder) writeNode(key dictionary.EntryKey, childNames []string, isRoot bool) {
+	members := code.IndentedCodeBuilder{}
+	for _, child := range childNames {
+		childClass := nodeClassName(key.NewChild(child))
+		if isRoot {
+			members.AppendLines(fmt.Sprintf("val Donggu.%s: %s get() = %s(this)", nodePropertyName(child), childClass, childClass))
+		} else {
+			members.AppendLines(fmt.Sprintf("val %s: %s get() = %s(_cb)", nodePropertyName(child), childClass, childClass))
+		}
+	}
+	if len(childNames) > 0 && len(k.memberBuilder.Commands) > 0 {
+		members.AppendLines("")
+	}
+	members.AppendBlock(k.memberBuilder)
+	k.memberBuilder = code.IndentedCodeBuilder{}
+
+	if isRoot {
+		k.nodeBuilder.AppendBlock(members)
+		return
+	}
+	k.nodeBuilder.AppendLines(fmt.Sprintf("class %s internal constructor(private val _cb: Donggu) {", nodeClassName(key)))
+	k.nodeBuilder.IndentedBlock(members)
+	k.nodeBuilder.AppendLines("}", "")
+}
+
+// writeEntryMethod writes the method of an entry to memberBuilder.
+// Self entries are written as the invoke operator of their node.
+func (k *kotlinCodeBuilder) writeEntryMethod(key dictionary.EntryKey, entry dictionary.Entry, params []kotlinParameter, isSelfEntry, isRoot bool) {
+	if len(k.memberBuilder.Commands) > 0 {
+		k.memberBuilder.AppendLines("")
+	}
+	k.memberBuilder.AppendLines("/**", fmt.Sprintf(" * Text of entry `%s`.", key))
+	if context := entry["context"]; context != "" {
+		k.memberBuilder.AppendLines(" *", " * "+docText(context))
+	}
+	k.memberBuilder.AppendLines(" *")
+	for _, lang := range sortedLanguages(entry) {
+		k.memberBuilder.AppendLines(fmt.Sprintf(" * - `%s`: `%s`", lang, docText(entry[lang])))
+	}
+	k.memberBuilder.AppendLines(" */")
+
+	declarations := make([]string, 0, len(params))
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		declarations = append(declarations, param.name+": "+param.typeName)
+		names = append(names, param.name)
+	}
+
+	signature := "fun " + entryMethodName(key)
+	resolver := "_cb.resolve"
+	if isSelfEntry {
+		signature = "operator fun invoke"
+	}
+	if isRoot {
+		signature = "fun Donggu." + entryMethodName(key)
+		resolver = "resolve"
+	}
+	k.memberBuilder.AppendLines(fmt.Sprintf(
+		"%s(%s): String = %s(%s, %s)(%s)",
+		signature, strings.Join(declarations, ", "),
+		resolver, stringLiteral(string(key)), entryFormattersName(key), strings.Join(names, ", "),
+	))
+}
+
+// writeEntryFormatters writes the map of formatter lambdas of an entry, keyed by language.
+func (k *kotlinCodeBuilder) writeEntryFormatters(key dictionary.EntryKey, params []kotlinParameter, impls map[string]kotlinFormatterImpl) {
+	paramTypes := make([]string, 0, len(params))
+	for _, param := range params {
+		paramTypes = append(paramTypes, param.typeName)
+	}
+	k.dataBuilder.AppendLines(fmt.Sprintf(
+		"internal val %s: Map<String, (%s) -> String> = mapOf(",
+		entryFormattersName(key), strings.Join(paramTypes, ", "),
+	))
+	k.dataBuilder.Indent()
+
+	languages := make([]string, 0, len(impls))
+	for lang := range impls {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	for _, lang := range languages {
+		impl := impls[lang]
+		if len(params) == 0 {
+			k.dataBuilder.AppendLines(fmt.Sprintf("%s to { %s },", stringLiteral(lang), impl.body))
+			continue
+		}
+		declarations := make([]string, 0, len(params))
+		for _, param := range params {
+			name := param.name
+			if _, ok := impl.usedParams[name]; !ok {
+				name = "_"
+			}
+			declarations = append(declarations, name+": "+param.typeName)
+		}
+		k.dataBuilder.AppendLines(fmt.Sprintf("%s to { %s -> %s },", stringLiteral(lang), strings.Join(declarations, ", "), impl.body))
+	}
+	k.dataBuilder.Unindent()
+	k.dataBuilder.AppendLines(")", "")
+}
+
+func (k *kotlinCodeBuilder) writePluralSelectorImpl(language string, metadata *dictionary.Metadata) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(fmt.Sprintf("internal fun %s(value: Int): String {", pluralSelectorFnName(language)))
+	builder.Indent()
+
+	if rules, ok := metadata.PluralRules(language); ok {
+		k.writeCldrPluralSelectorBody(&builder, rules)
+	} else {
+		defs, defsOk := metadata.Plurals[language]
+		if !defsOk {
+			defs = dictionary.DefaultPluralDefinition()
+		}
+		for index, def := range defs {
+			builder.AppendLines(fmt.Sprintf("if (%s) return %s", def.IntegerExpression("value"), stringLiteral(string(def.CategoryName(index)))))
+		}
+		builder.AppendLines(fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+
+	builder.Unindent()
+	builder.AppendLines("}")
+	return builder
+}
+
+// writeCldrPluralSelectorBody writes a plural selector from CLDR plural rules.
+// Plural values are always integers, so relations on fraction operands are evaluated in advance.
+func (k *kotlinCodeBuilder) writeCldrPluralSelectorBody(builder *code.IndentedCodeBuilder, rules []dictionary.PluralRule) {
+	lines := []string{}
+	alwaysMatched := false
+	for _, rule := range rules {
+		condition := rule.Condition.IntegerOnly()
+		if len(condition) == 0 {
+			continue
+		}
+		if len(condition[0]) == 0 {
+			lines = append(lines, fmt.Sprintf("return %s", stringLiteral(string(rule.Category))))
+			alwaysMatched = true
+			break
+		}
+		lines = append(lines, fmt.Sprintf("if (%s) return %s", condition.IntegerExpression("n"), stringLiteral(string(rule.Category))))
+	}
+	if !alwaysMatched {
+		lines = append(lines, fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+	if len(lines) > 1 {
+		builder.AppendLines("val n = if (value < 0) -value else value")
+	}
+	builder.AppendLines(lines...)
+}
+
+// docText converts a text to be used in a single line of a KDoc comment.
+func docText(text string) string {
+	text = strings.ReplaceAll(text, "\n", `\n`)
+	return strings.ReplaceAll(text, "*/", "*&#47;")
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	languages := make([]string, 0, len(entry))
+	for lang := range entry {
+		if !dictionary.IsEntryMetaField(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,47 @@
+package kotlin
+
+import (
+	"strings"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// kotlinHardKeywords are keywords which cannot be used as identifiers without backticks.
+var kotlinHardKeywords = map[string]struct{}{
+	"as": {}, "break": {}, "class": {}, "continue": {}, "do": {}, "else": {}, "false": {}, "for": {},
+	"fun": {}, "if": {}, "in": {}, "interface": {}, "is": {}, "null": {}, "object": {}, "package": {},
+	"return": {}, "super": {}, "this": {}, "throw": {}, "true": {}, "try": {}, "typealias": {},
+	"typeof": {}, "val": {}, "var": {}, "when": {}, "while": {},
+}
+
+func identifier(name string) string {
+	if _, ok := kotlinHardKeywords[name]; ok {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func nodeClassName(key dictionary.EntryKey) string {
+	return "D_" + key.PascalCase()
+}
+
+func nodePropertyName(child string) string {
+	return identifier(code.ToCamelCase(child))
+}
+
+func entryMethodName(key dictionary.EntryKey) string {
+	return identifier(code.ToCamelCase(key.LastPart()))
+}
+
+func argumentName(templateKey string) string {
+	return identifier(code.TemplateKeyToCamelCase(templateKey))
+}
+
+func entryFormattersName(key dictionary.EntryKey) string {
+	return "d_" + key.PascalCase() + "_Fmt"
+}
+
+func pluralSelectorFnName(language string) string {
+	return "l_plural_" + strings.ReplaceAll(language, "-", "_")
+}
@@ -0,0 +1,162 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// libraryMessage is a message converted for a localization library.
+// Suffix is added to the entry key, for libraries which keep plural forms in separate keys.
+type libraryMessage struct {
+	Suffix string
+	Text   string
+}
+
+// libraryMessageFormatter converts the text of an entry in a language to the messages of a localization library.
+type libraryMessageFormatter func(entry dictionary.Entry, lang string, metadata dictionary.Metadata) ([]libraryMessage, error)
+
+// exportLibraryMessages writes the messages of all supported languages as a single JSON object keyed by language.
+func exportLibraryMessages(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	nested bool,
+	format libraryMessageFormatter,
+) error {
+	languages := map[string]interface{}{}
+	for _, lang := range metadata.SupportedLanguages {
+		messages, err := buildLibraryMessages(content, lang, metadata, nested, format)
+		if err != nil {
+			return err
+		}
+		languages[lang] = messages
+	}
+	return writeLibraryMessages(file, languages)
+}
+
+// exportLibraryMessageFiles writes the messages of each supported language to `<language>.json` in projectRoot.
+func exportLibraryMessageFiles(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	nested bool,
+	format libraryMessageFormatter,
+) error {
+	for _, lang := range metadata.SupportedLanguages {
+		messages, err := buildLibraryMessages(content, lang, metadata, nested, format)
+		if err != nil {
+			return err
+		}
+		langFile, err := os.OpenFile(path.Join(projectRoot, lang+".json"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open file for language '%s'", lang)
+		}
+		err = writeLibraryMessages(langFile, messages)
+		langFile.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to write language '%s'", lang)
+		}
+	}
+	return nil
+}
+
+// buildLibraryMessages converts the texts of a language, keyed by entry keys or nested by the parts of entry keys.
+// Entries without the language are left out, so that libraries can fall back to other languages.
+//
+// When nested, the messages of an entry under an entry with a text, such as `greet.sub` under `greet`,
+// are written with their flat keys at the top level, as the text cannot also be an object.
+// i18next and vue-i18n look up the flat key at the top level if the nested lookup fails.
+func buildLibraryMessages(
+	content dictionary.ContentRepresentation,
+	lang string,
+	metadata dictionary.Metadata,
+	nested bool,
+	format libraryMessageFormatter,
+) (map[string]interface{}, error) {
+	flattened := content.ToFlattened()
+	root := map[string]interface{}{}
+	for _, key := range sortedEntryKeys(flattened) {
+		entry := (*flattened)[key]
+		if _, ok := entry[lang]; !ok {
+			continue
+		}
+		messages, err := format(entry, lang, metadata)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert '%s' (%s)", key, lang)
+		}
+
+		for _, message := range messages {
+			if !nested || hasTextAncestor(flattened, key, lang) {
+				root[string(key)+message.Suffix] = message.Text
+				continue
+			}
+			object := root
+			parts := key.Parts()
+			for _, part := range parts[:len(parts)-1] {
+				if _, ok := object[part]; !ok {
+					object[part] = map[string]interface{}{}
+				}
+				child, ok := object[part].(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("'%s' conflicts with a message of another entry, such as a plural form", key)
+				}
+				object = child
+			}
+			name := parts[len(parts)-1] + message.Suffix
+			if _, ok := object[name]; ok {
+				return nil, errors.Errorf("'%s' conflicts with a message of another entry, such as a plural form", key)
+			}
+			object[name] = message.Text
+		}
+	}
+	return root, nil
+}
+
+// hasTextAncestor returns whether an ancestor of key has a text of the language.
+func hasTextAncestor(flattened *dictionary.FlattenedContent, key dictionary.EntryKey, lang string) bool {
+	for parent := key.Parent(); parent != ""; parent = parent.Parent() {
+		if _, ok := (*flattened)[parent][lang]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func writeLibraryMessages(file io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return errors.Wrap(err, "failed to encode JSON")
+	}
+	return nil
+}
+
+// libraryNestedOption returns the 'nested' option of library message exporters, or defaultNested if not given.
+func libraryNestedOption(options OptionMap, defaultNested bool) bool {
+	if nested, ok := nestedOption(options); ok {
+		return nested
+	}
+	return defaultNested
+}
+
+// libraryPluralKey returns the key of the plural template used by the text of an entry in a language,
+// or an empty string if the text has no plural templates.
+func libraryPluralKey(text string) (string, error) {
+	pluralKey := ""
+	for _, match := range templateOptionRegex.FindAllStringSubmatch(text, -1) {
+		if match[2] != string(dictionary.PluralTemplateKeyType) {
+			continue
+		}
+		if pluralKey != "" && pluralKey != match[1] {
+			return "", errors.Errorf("plural templates with different keys '%s' and '%s' are not supported", pluralKey, match[1])
+		}
+		pluralKey = match[1]
+	}
+	return pluralKey, nil
+}
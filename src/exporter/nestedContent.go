@@ -0,0 +1,116 @@
+package exporter
+
+import (
+	"sort"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// selfEntryName is the name of the member holding the fields of an entry in the nested form,
+// when a field of the entry has the same name as its child.
+const selfEntryName = "$"
+
+// nestedMember is a member of a node in the nested content form, such as `login` of `{"screens": {"login": ...}}`.
+// The member has the fields of the entry with its name, followed by the children with its name.
+type nestedMember struct {
+	Name   string
+	Key    dictionary.EntryKey
+	Fields []string
+	Entry  dictionary.Entry
+	Child  *dictionary.ContentNode
+	// SelfEntry is whether the fields should be written under a member named selfEntryName,
+	// as a field has the same name as a child.
+	SelfEntry bool
+}
+
+// nestedMembers returns the members of a node in the order of names.
+func nestedMembers(node *dictionary.ContentNode) []nestedMember {
+	names := make([]string, 0, len(node.Entries)+len(node.Children))
+	for name := range node.Entries {
+		names = append(names, name)
+	}
+	for name := range node.Children {
+		if _, ok := node.Entries[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	members := make([]nestedMember, 0, len(names))
+	for _, name := range names {
+		member := nestedMember{
+			Name:   name,
+			Key:    node.Key.NewChild(name),
+			Fields: sortedEntryFields(node.Entries[name]),
+			Entry:  node.Entries[name],
+			Child:  node.Children[name],
+		}
+		if member.Child != nil {
+			for _, field := range member.Fields {
+				_, isEntry := member.Child.Entries[field]
+				_, isChild := member.Child.Children[field]
+				if isEntry || isChild {
+					member.SelfEntry = true
+				}
+			}
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// sortedEntryKeys returns the keys of content in order.
+func sortedEntryKeys(content *dictionary.FlattenedContent) []dictionary.EntryKey {
+	keys := make([]dictionary.EntryKey, 0, len(*content))
+	for key := range *content {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// sortedEntryFields returns the fields of an entry in order.
+func sortedEntryFields(entry dictionary.Entry) []string {
+	fields := make([]string, 0, len(entry))
+	for field := range entry {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// nestedOption returns the 'nested' option of structured content formats, which writes content in the nested form.
+func nestedOption(options OptionMap) (nested bool, ok bool) {
+	value, exists := options["nested"]
+	if !exists {
+		return false, false
+	}
+	nested, ok = value.(bool)
+	return nested, ok
+}
+
+func validateNestedOption(options OptionMap) error {
+	if _, exists := options["nested"]; !exists {
+		return nil
+	}
+	if _, ok := nestedOption(options); !ok {
+		return errors.New("option 'nested' should be a boolean")
+	}
+	return nil
+}
+
+// isNestedContentValue returns whether decoded content is written in the nested form,
+// which has a subtree under a top-level key.
+func isNestedContentValue(value map[string]interface{}) bool {
+	for _, member := range value {
+		if fields, ok := member.(map[string]interface{}); ok {
+			for _, field := range fields {
+				if _, ok := field.(map[string]interface{}); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// PoTemplateFileName is the name of the POT file written by PoDictionaryExporter.Export.
+const PoTemplateFileName = "messages.pot"
+
+var templateOptionRegex = regexp.MustCompile(dictionary.TemplateOptionPattern)
+
+// PoDictionaryExporter is a DictionaryExporter for GNU gettext catalogs.
+//
+// Entry keys are used as msgid, and entry contexts are written as extracted comments.
+// Texts with plural templates are written as plural entries, with one msgstr for each
+// plural category of the language. msgid_plural holds the key of the plural template (ie. `#{COUNT}`).
+//
+// ExportContent writes the POT template, and Export writes a PO file for each language
+// along with the template.
+type PoDictionaryExporter struct{}
+
+func (p PoDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	templateFile, err := os.OpenFile(path.Join(projectRoot, PoTemplateFileName), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return errors.Wrap(err, "failed to open template file")
+	}
+	defer templateFile.Close()
+	if err := p.ExportContent(templateFile, content, metadata, options); err != nil {
+		return errors.Wrap(err, "failed to write template")
+	}
+
+	for _, lang := range metadata.SupportedLanguages {
+		langFile, err := os.OpenFile(path.Join(projectRoot, lang+".po"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open file for language '%s'", lang)
+		}
+		err = p.writeCatalog(langFile, lang, content, metadata)
+		langFile.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to write language '%s'", lang)
+		}
+	}
+	return nil
+}
+
+func (p PoDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	_ OptionMap,
+) error {
+	return p.writeCatalog(file, "", content, metadata)
+}
+
+func (p PoDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (p PoDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return nil
+}
+
+// writeCatalog writes a PO file for lang, or the POT template if lang is empty.
+func (p PoDictionaryExporter) writeCatalog(file io.Writer, lang string, content dictionary.ContentRepresentation, metadata dictionary.Metadata) error {
+	flattened := content.ToFlattened()
+	keys := make([]string, 0, len(*flattened))
+	for key := range *flattened {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	builder := strings.Builder{}
+	builder.WriteString(poHeader(lang, metadata))
+
+	for _, key := range keys {
+		entry := (*flattened)[dictionary.EntryKey(key)]
+		pluralKey, err := poPluralKey(entry, metadata)
+		if err != nil {
+			return errors.Wrapf(err, "invalid entry '%s'", key)
+		}
+
+		builder.WriteString("\n")
+		if context := entry["context"]; context != "" {
+			for _, line := range strings.Split(context, "\n") {
+				builder.WriteString("#. " + line + "\n")
+			}
+		}
+		writePoString(&builder, "msgid", key)
+
+		if pluralKey == "" {
+			writePoString(&builder, "msgstr", entry[lang])
+			continue
+		}
+		writePoString(&builder, "msgid_plural", fmt.Sprintf("#{%s}", pluralKey))
+		if lang == "" {
+			writePoString(&builder, "msgstr[0]", "")
+			writePoString(&builder, "msgstr[1]", "")
+			continue
+		}
+		forms, err := poPluralForms(entry[lang], lang, metadata)
+		if err != nil {
+			return errors.Wrapf(err, "invalid entry '%s'", key)
+		}
+		for index, form := range forms {
+			writePoString(&builder, fmt.Sprintf("msgstr[%d]", index), form)
+		}
+	}
+
+	if _, err := io.WriteString(file, builder.String()); err != nil {
+		return errors.Wrap(err, "error while writing file")
+	}
+	return nil
+}
+
+func poHeader(lang string, metadata dictionary.Metadata) string {
+	pluralForms := "nplurals=INTEGER; plural=EXPRESSION;"
+	if lang != "" {
+		pluralForms = fmt.Sprintf("nplurals=%d; plural=%s;", len(metadata.PluralCategories(lang)), poPluralExpression(lang, metadata))
+	}
+
+	fields := []string{
+		"Project-Id-Version: " + metadata.Version,
+		"Language: " + lang,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+		"Plural-Forms: " + pluralForms,
+	}
+	builder := strings.Builder{}
+	builder.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	for _, field := range fields {
+		builder.WriteString(poQuote(field+"\n") + "\n")
+	}
+	return builder.String()
+}
+
+// poPluralKey returns the key of the plural template used by the entry, or an empty string
+// if no language uses plural templates.
+func poPluralKey(entry dictionary.Entry, metadata dictionary.Metadata) (string, error) {
+	pluralKey := ""
+	for _, lang := range metadata.SupportedLanguages {
+		for _, match := range templateOptionRegex.FindAllStringSubmatch(entry[lang], -1) {
+			if match[2] != string(dictionary.PluralTemplateKeyType) {
+				continue
+			}
+			if pluralKey != "" && pluralKey != match[1] {
+				return "", errors.Errorf("plural templates with different keys '%s' and '%s' cannot be written to PO files", pluralKey, match[1])
+			}
+			pluralKey = match[1]
+		}
+	}
+	return pluralKey, nil
+}
+
+// poPluralForms expands the plural templates of a text to one text for each plural category of the language.
+func poPluralForms(text, lang string, metadata dictionary.Metadata) ([]string, error) {
+	categories := metadata.PluralCategories(lang)
+	forms := make([]string, len(categories))
+	for index, category := range categories {
+		var err error
+		forms[index] = templateOptionRegex.ReplaceAllStringFunc(text, func(template string) string {
+			match := templateOptionRegex.FindStringSubmatch(template)
+			if match[2] != string(dictionary.PluralTemplateKeyType) {
+				return template
+			}
+			format, formatErr := dictionary.ParseTemplateKeyFormat(match[2], match[3])
+			if formatErr != nil {
+				err = formatErr
+				return template
+			}
+			choices, choiceErr := format.Option.(dictionary.PluralTemplateFormatOption).ChoicesFor(categories)
+			if choiceErr != nil {
+				err = choiceErr
+				return template
+			}
+			if choice, ok := choices[category]; ok {
+				return choice
+			}
+			return choices[dictionary.OtherPluralCategory]
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return forms, nil
+}
+
+// poPluralExpression builds the C expression of the Plural-Forms header of a language.
+// The expression evaluates to the index of the plural category in metadata.PluralCategories.
+func poPluralExpression(lang string, metadata dictionary.Metadata) string {
+	categories := metadata.PluralCategories(lang)
+	branches := []poPluralBranch{}
+
+	if rules, ok := metadata.PluralRules(lang); ok {
+		for index, rule := range rules {
+			condition := rule.Condition.IntegerOnly()
+			if len(condition) == 0 {
+				continue
+			}
+			if len(condition[0]) == 0 {
+				return poTernary(branches, index)
+			}
+			branches = append(branches, poPluralBranch{condition: condition.IntegerExpression("n"), index: index})
+		}
+	} else {
+		defs, ok := metadata.Plurals[lang]
+		if !ok {
+			defs = dictionary.DefaultPluralDefinition()
+		}
+		for index, def := range defs {
+			branches = append(branches, poPluralBranch{condition: def.IntegerExpression("n"), index: index})
+		}
+	}
+	return poTernary(branches, len(categories)-1)
+}
+
+type poPluralBranch struct {
+	condition string
+	index     int
+}
+
+// poTernary chains branches so that the index of the first matching branch is selected.
+// fallback is selected if no branch matches.
+func poTernary(branches []poPluralBranch, fallback int) string {
+	builder := strings.Builder{}
+	for _, branch := range branches {
+		builder.WriteString(fmt.Sprintf("(%s) ? %d : ", branch.condition, branch.index))
+	}
+	builder.WriteString(fmt.Sprint(fallback))
+	return builder.String()
+}
+
+// writePoString writes a keyword and its quoted value.
+// Multiline values are split into one string per line.
+func writePoString(builder *strings.Builder, keyword, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 1 {
+		builder.WriteString(fmt.Sprintf("%s %s\n", keyword, poQuote(value)))
+		return
+	}
+	builder.WriteString(keyword + " \"\"\n")
+	for _, line := range lines {
+		builder.WriteString(poQuote(line) + "\n")
+	}
+}
+
+func poQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + replacer.Replace(value) + `"`
+}
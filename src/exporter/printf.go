@@ -0,0 +1,228 @@
+package exporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// printfDialect describes the positional format specifiers of a platform.
+type printfDialect struct {
+	stringVerb string
+	intVerb    string
+	floatVerb  string
+	// groupingFlag is the flag for comma separators. Empty if the platform does not support it.
+	groupingFlag string
+}
+
+var javaPrintfDialect = printfDialect{stringVerb: "s", intVerb: "d", floatVerb: "f", groupingFlag: ","}
+var cocoaPrintfDialect = printfDialect{stringVerb: "@", intVerb: "ld", floatVerb: "f"}
+
+// printfEntry converts the texts of an entry to printf style format strings.
+// Arguments are numbered in the order they appear in the source language, so that
+// the position of an argument is the same in every language.
+type printfEntry struct {
+	entry     dictionary.Entry
+	metadata  dictionary.Metadata
+	keys      []string
+	positions map[string]int
+	// pluralKey is the key of the plural template used by the entry. Empty if there is none.
+	pluralKey string
+}
+
+func newPrintfEntry(entry dictionary.Entry, metadata dictionary.Metadata) (*printfEntry, error) {
+	p := &printfEntry{entry: entry, metadata: metadata, positions: map[string]int{}}
+
+	languages := append([]string{}, metadata.RequiredLanguages...)
+	languages = append(languages, metadata.SupportedLanguages...)
+	for index, lang := range languages {
+		added := []string{}
+		for _, match := range templateOptionRegex.FindAllStringSubmatch(entry[lang], -1) {
+			key := match[1]
+			if match[2] == string(dictionary.PluralTemplateKeyType) {
+				if p.pluralKey != "" && p.pluralKey != key {
+					return nil, errors.Errorf("plural templates with different keys '%s' and '%s' are not supported", p.pluralKey, key)
+				}
+				p.pluralKey = key
+			}
+			if _, ok := p.positions[key]; !ok {
+				p.positions[key] = -1
+				added = append(added, key)
+			}
+		}
+		// Keys not used in the source language are numbered in alphabetical order.
+		if index > 0 {
+			sort.Strings(added)
+		}
+		p.keys = append(p.keys, added...)
+	}
+	for index, key := range p.keys {
+		p.positions[key] = index + 1
+	}
+	return p, nil
+}
+
+// HasArguments reports whether the format strings of the entry consume any arguments.
+func (p *printfEntry) HasArguments() bool {
+	return len(p.keys) > 0
+}
+
+// HasPlural reports whether the text of a language has a plural template.
+func (p *printfEntry) HasPlural(lang string) bool {
+	for _, match := range templateOptionRegex.FindAllStringSubmatch(p.entry[lang], -1) {
+		if match[2] == string(dictionary.PluralTemplateKeyType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Format returns the format string of a language.
+// Plural templates are replaced with the choice for category.
+func (p *printfEntry) Format(lang string, category dictionary.PluralCategory, dialect printfDialect) (string, error) {
+	return p.FormatWith(lang, dialect, func(_ string, choices map[dictionary.PluralCategory]string) string {
+		if choice, ok := choices[category]; ok {
+			return p.escapeLiteral(choice)
+		}
+		return p.escapeLiteral(choices[dictionary.OtherPluralCategory])
+	})
+}
+
+// FormatWith returns the format string of a language.
+// Plural templates are replaced with the return value of pluralFn, which is given the key
+// and the choices of the template.
+func (p *printfEntry) FormatWith(
+	lang string,
+	dialect printfDialect,
+	pluralFn func(key string, choices map[dictionary.PluralCategory]string) string,
+) (string, error) {
+	text := p.entry[lang]
+	builder := strings.Builder{}
+	lastIndex := 0
+	for _, match := range templateOptionRegex.FindAllStringSubmatchIndex(text, -1) {
+		builder.WriteString(p.escapeLiteral(text[lastIndex:match[0]]))
+		lastIndex = match[1]
+
+		key := text[match[2]:match[3]]
+		kind, option := "", ""
+		if match[4] != -1 {
+			kind = text[match[4]:match[5]]
+		}
+		if match[6] != -1 {
+			option = text[match[6]:match[7]]
+		}
+		format, err := dictionary.ParseTemplateKeyFormat(kind, option)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid template for key '%s'", key)
+		}
+
+		switch format.Kind {
+		case dictionary.StringTemplateKeyType, dictionary.BoolTemplateKeyType:
+			// Bool templates have no format specifier, so the text of the value is given as a string argument.
+			// The values of the language are listed by ArgumentDescription.
+			builder.WriteString(fmt.Sprintf("%%%d$%s", p.positions[key], dialect.stringVerb))
+		case dictionary.IntTemplateKeyType:
+			builder.WriteString(p.numericSpecifier(key, format, dialect) + dialect.intVerb)
+		case dictionary.FloatTemplateKeyType:
+			builder.WriteString(p.numericSpecifier(key, format, dialect) + dialect.floatVerb)
+		case dictionary.PluralTemplateKeyType:
+			choices, err := format.Option.(dictionary.PluralTemplateFormatOption).ChoicesFor(p.metadata.PluralCategories(lang))
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid plural template for key '%s'", key)
+			}
+			builder.WriteString(pluralFn(key, choices))
+		default:
+			return "", errors.Errorf("template key '%s' of type '%s' has no format specifier", key, format.Kind)
+		}
+	}
+	builder.WriteString(p.escapeLiteral(text[lastIndex:]))
+	return builder.String(), nil
+}
+
+// PluralCategories returns the plural categories of a language, checking that they are CLDR categories.
+func (p *printfEntry) PluralCategories(lang string) ([]dictionary.PluralCategory, error) {
+	categories := p.metadata.PluralCategories(lang)
+	for _, category := range categories {
+		if _, ok := cldrPluralCategorySet[category]; !ok {
+			return nil, errors.Errorf("plural category '%s' of language '%s' is not a CLDR plural category", category, lang)
+		}
+	}
+	return categories, nil
+}
+
+// Position returns the argument position of a template key.
+func (p *printfEntry) Position(key string) int {
+	return p.positions[key]
+}
+
+// ArgumentDescription describes the position of each argument, such as `1: NAME, 2: COUNT`.
+// Bool templates of the language are described with their values, such as `3: ON (bool: true "yes", false "no")`.
+func (p *printfEntry) ArgumentDescription(lang string) string {
+	boolValues := map[string]string{}
+	for _, match := range templateOptionRegex.FindAllStringSubmatch(p.entry[lang], -1) {
+		if match[2] != string(dictionary.BoolTemplateKeyType) {
+			continue
+		}
+		format, err := dictionary.ParseTemplateKeyFormat(match[2], match[3])
+		if err != nil {
+			continue
+		}
+		option := format.Option.(dictionary.BoolTemplateFormatOption)
+		if option.UseLocaleValues {
+			boolValues[match[1]] = "bool: true or false of the locale"
+		} else {
+			boolValues[match[1]] = fmt.Sprintf("bool: true %q, false %q", option.TrueValue, option.FalseValue)
+		}
+	}
+
+	parts := make([]string, 0, len(p.keys))
+	for _, key := range p.keys {
+		part := fmt.Sprintf("%d: %s", p.positions[key], key)
+		if values, ok := boolValues[key]; ok {
+			part += " (" + values + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (p *printfEntry) numericSpecifier(key string, format dictionary.TemplateKeyFormat, dialect printfDialect) string {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	specifier := fmt.Sprintf("%%%d$", p.positions[key])
+	if option.AlwaysAddSign {
+		specifier += "+"
+	}
+	if option.CommaSeparator {
+		specifier += dialect.groupingFlag
+	}
+	if option.PadCharacter == "0" {
+		specifier += "0"
+	}
+	if option.WidthSet {
+		specifier += fmt.Sprint(option.Width)
+	}
+	if option.PrecisionSet {
+		specifier += fmt.Sprintf(".%d", option.Precision)
+	}
+	return specifier
+}
+
+// escapeLiteral escapes '%' in literal text, if the text is used as a format string.
+func (p *printfEntry) escapeLiteral(text string) string {
+	if !p.HasArguments() {
+		return text
+	}
+	return strings.ReplaceAll(text, "%", "%%")
+}
+
+var cldrPluralCategorySet = map[dictionary.PluralCategory]struct{}{
+	dictionary.ZeroPluralCategory:  {},
+	dictionary.OnePluralCategory:   {},
+	dictionary.TwoPluralCategory:   {},
+	dictionary.FewPluralCategory:   {},
+	dictionary.ManyPluralCategory:  {},
+	dictionary.OtherPluralCategory: {},
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"os"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter/python"
+	"github.com/pkg/errors"
+)
+
+// PythonDictionaryExporter is a DictionaryProjectExporter
+// generating a Python package for using the dictionary.
+//
+// The project root is the package folder, which can be imported with the name of the folder.
+type PythonDictionaryExporter struct{}
+
+func (p PythonDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	if err := p.prepareProject(projectRoot); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+
+	builder := python.NewPythonBuilder(metadata)
+	if err := builder.Run(content.ToTree()); err != nil {
+		return err
+	}
+
+	return builder.Build(metadata, projectRoot)
+}
+
+func (p PythonDictionaryExporter) prepareProject(projectRoot string) error {
+	if err := os.RemoveAll(projectRoot); err != nil {
+		return err
+	}
+	if err := code.CopyTemplateTo("python", projectRoot, code.CopyTemplateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+	return nil
+}
+
+func (p PythonDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return nil
+}
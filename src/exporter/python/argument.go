@@ -0,0 +1,112 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var pythonStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+)
+
+// stringLiteral returns text as a Python string literal.
+func stringLiteral(text string) string {
+	return `"` + pythonStringEscaper.Replace(text) + `"`
+}
+
+type pythonArgumentFormatter struct {
+	metadata *dictionary.Metadata
+}
+
+func (p pythonArgumentFormatter) ArgumentType(argType dictionary.TemplateKeyFormat) string {
+	switch argType.Kind {
+	case dictionary.IntTemplateKeyType:
+		return "int"
+	case dictionary.FloatTemplateKeyType:
+		return "float"
+	case dictionary.BoolTemplateKeyType:
+		return "bool"
+	case dictionary.PluralTemplateKeyType:
+		return "int"
+	default:
+		return "str"
+	}
+}
+
+// Format returns a string expression for a template.
+func (p pythonArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
+	name := argumentName(key)
+	switch format.Kind {
+	case dictionary.IntTemplateKeyType:
+		return p.formatNumeric(name, "d", format), nil
+	case dictionary.FloatTemplateKeyType:
+		return p.formatNumeric(name, "f", format), nil
+	case dictionary.BoolTemplateKeyType:
+		return p.formatBool(name, format), nil
+	case dictionary.PluralTemplateKeyType:
+		return p.formatPlural(language, name, format)
+	default:
+		return name, nil
+	}
+}
+
+func (p pythonArgumentFormatter) formatPlural(language, name string, format dictionary.TemplateKeyFormat) (string, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(p.metadata.PluralCategories(language))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid plural choices")
+	}
+	categories := make([]string, 0, len(choices))
+	for category := range choices {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	pairs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", stringLiteral(category), stringLiteral(choices[dictionary.PluralCategory(category)])))
+	}
+	return fmt.Sprintf(
+		"runtime.select_plural_choice(language.%s(%s), {%s})",
+		pluralSelectorFnName(language), name, strings.Join(pairs, ", "),
+	), nil
+}
+
+func (p pythonArgumentFormatter) formatBool(name string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.BoolTemplateFormatOption)
+	if option.UseLocaleValues {
+		return fmt.Sprintf(`runtime.print_boolean_value(%s, "true", "false")`, name)
+	}
+	return fmt.Sprintf("runtime.print_boolean_value(%s, %s, %s)", name, stringLiteral(option.TrueValue), stringLiteral(option.FalseValue))
+}
+
+// formatNumeric formats a number with the format specification mini-language.
+func (p pythonArgumentFormatter) formatNumeric(name, presentation string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	if option.IsZero() && presentation == "d" {
+		return fmt.Sprintf("str(%s)", name)
+	}
+	spec := ""
+	if option.AlwaysAddSign {
+		spec += "+"
+	}
+	if option.PadCharacter == "0" && option.WidthSet {
+		spec += "0"
+	}
+	if option.WidthSet {
+		spec += fmt.Sprintf("%d", option.Width)
+	}
+	if option.CommaSeparator {
+		spec += ","
+	}
+	// Precision is not allowed for integers.
+	if option.PrecisionSet && presentation == "f" {
+		spec += fmt.Sprintf(".%d", option.Precision)
+	}
+	spec += presentation
+	return fmt.Sprintf("format(%s, %s)", name, stringLiteral(spec))
+}
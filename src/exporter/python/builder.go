@@ -0,0 +1,194 @@
+package python
+
+import (
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+type PythonBuilder struct {
+	builder          *pythonCodeBuilder
+	metadata         *dictionary.Metadata
+	contentValidator dictionary.ContentValidator
+}
+
+func NewPythonBuilder(metadata dictionary.Metadata) *PythonBuilder {
+	return &PythonBuilder{
+		builder:  newPythonCodeBuilder(),
+		metadata: &metadata,
+		contentValidator: dictionary.NewContentValidator(metadata, dictionary.ContentValidationOptions{
+			SkipLangSupportCheck: true,
+		}),
+	}
+}
+
+func (p *PythonBuilder) Build(metadata dictionary.Metadata, projectRoot string) error {
+	now := time.Now()
+
+	operations := map[string]func(f *os.File) error{
+		"data.py": func(f *os.File) error {
+			return p.builder.outputDataFile(f, now)
+		},
+		"language.py": func(f *os.File) error {
+			return p.builder.outputLanguageFile(f, metadata, now)
+		},
+		"nodes.py": func(f *os.File) error {
+			return p.builder.outputNodeFile(f, now)
+		},
+	}
+
+	for filename, saveFile := range operations {
+		file, err := p.openFile(projectRoot, filename)
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+		err = saveFile(file)
+		file.Close()
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+	}
+	return nil
+}
+
+func (p *PythonBuilder) openFile(projectRoot, filename string) (*os.File, error) {
+	f, err := os.OpenFile(
+		path.Join(projectRoot, "generated", filename),
+		os.O_CREATE|os.O_TRUNC|os.O_RDWR,
+		os.ModePerm,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open '%s'", filename)
+	}
+	return f, err
+}
+
+func (p *PythonBuilder) Run(content *dictionary.ContentNode) error {
+	return p.walk(content, dictionary.EntryKey(""), nil, 0)
+}
+
+func (p *PythonBuilder) walk(
+	contentNode *dictionary.ContentNode,
+	positionKey dictionary.EntryKey,
+	selfNameEntry dictionary.Entry,
+	depth int,
+) error {
+	childNames := make([]string, 0, len(contentNode.Children))
+
+	entriesToSkip := map[string]struct{}{}
+
+	for _, key := range sortedKeys(contentNode.Children) {
+		child := contentNode.Children[key]
+		childNames = append(childNames, key)
+
+		var err error
+		if _, ok := contentNode.Entries[key]; ok {
+			entriesToSkip[key] = struct{}{}
+			err = p.walk(child, positionKey.NewChild(key), contentNode.Entries[key], depth+1)
+		} else {
+			err = p.walk(child, positionKey.NewChild(key), nil, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if selfNameEntry != nil {
+		err := p.addEntry(selfNameEntry, positionKey, true, false)
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(contentNode.Entries) {
+		if _, ok := entriesToSkip[key]; ok {
+			continue
+		}
+		err := p.addEntry(contentNode.Entries[key], positionKey.NewChild(key), false, depth == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.builder.writeNode(positionKey, childNames, depth == 0)
+	return nil
+}
+
+func (p *PythonBuilder) addEntry(entry dictionary.Entry, entryKey dictionary.EntryKey, isSelfEntry, isRoot bool) error {
+	templateKeys, validateErr := p.contentValidator.Validate(entry)
+	if validateErr != nil {
+		return errors.Wrapf(validateErr, "failed to add leaf '%s'", entryKey)
+	}
+	formatter := pythonArgumentFormatter{metadata: p.metadata}
+
+	params := make([]pythonParameter, 0, len(templateKeys))
+	for _, key := range sortedKeys(templateKeys) {
+		params = append(params, pythonParameter{
+			name:     argumentName(key),
+			typeName: formatter.ArgumentType(templateKeys[key]),
+		})
+	}
+
+	impls := map[string]string{}
+	for lang := range entry {
+		if dictionary.IsEntryMetaField(lang) {
+			continue
+		}
+		impl, err := p.buildFormatterImpl(entry, lang, templateKeys, formatter)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build formatter value of '%s'", entryKey)
+		}
+		impls[lang] = impl
+	}
+
+	p.builder.writeEntryMethod(entryKey, entry, params, isSelfEntry, isRoot)
+	p.builder.writeEntryFormatters(entryKey, params, impls)
+	return nil
+}
+
+// buildFormatterImpl returns the body of the formatter lambda of an entry,
+// which concatenates the literals and template values of the text.
+func (p *PythonBuilder) buildFormatterImpl(
+	entry dictionary.Entry,
+	lang string,
+	argTypes map[string]dictionary.TemplateKeyFormat,
+	formatter pythonArgumentFormatter,
+) (string, error) {
+	segments := []string{}
+	escapeFn := func(text string) string {
+		if text != "" {
+			segments = append(segments, stringLiteral(text))
+		}
+		return ""
+	}
+	_, err := entry.ReplacedTemplateValueEscaped(lang, escapeFn, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		if _, ok := argTypes[key]; !ok {
+			return "", errors.Errorf("unknown template key '%s'", key)
+		}
+		expression, formatErr := formatter.Format(lang, key, format)
+		if formatErr != nil {
+			return "", errors.Wrapf(formatErr, "failed to format template '%s'", key)
+		}
+		segments = append(segments, expression)
+		return "", nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse template parameter")
+	}
+	if len(segments) == 0 {
+		return `""`, nil
+	}
+	return strings.Join(segments, " + "), nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,280 @@
+package python
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// pythonOperatorReplacer converts C-style plural conditions to Python.
+var pythonOperatorReplacer = strings.NewReplacer("&&", "and", "||", "or", "!(", "not (", " / ", " // ")
+
+type pythonParameter struct {
+	name     string
+	typeName string
+}
+
+type pythonCodeBuilder struct {
+	nodeBuilder code.IndentedCodeBuilder
+	dataBuilder code.IndentedCodeBuilder
+	// memberBuilder holds the entry methods of the node being walked,
+	// until they are written with the node.
+	memberBuilder code.IndentedCodeBuilder
+}
+
+func newPythonCodeBuilder() *pythonCodeBuilder {
+	return &pythonCodeBuilder{}
+}
+
+func (p *pythonCodeBuilder) outputNodeFile(w io.Writer, now time.Time) error {
+	builder := p.fileBuilder(now)
+	builder.AppendLines(
+		"from dataclasses import dataclass",
+		"from typing import TYPE_CHECKING",
+		"",
+		"from . import data",
+		"",
+		"if TYPE_CHECKING:",
+		"  from ..donggu import Donggu",
+		"",
+	)
+	builder.AppendBlock(p.nodeBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (p *pythonCodeBuilder) outputDataFile(w io.Writer, now time.Time) error {
+	builder := p.fileBuilder(now)
+	builder.AppendLines(
+		"from typing import Callable, Dict",
+		"",
+		"from .. import runtime",
+		"from . import language",
+		"",
+	)
+	builder.AppendBlock(p.dataBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (p *pythonCodeBuilder) outputLanguageFile(w io.Writer, metadata dictionary.Metadata, now time.Time) error {
+	builder := p.fileBuilder(now)
+	builder.AppendLines("from typing import Dict", "")
+
+	requiredLangs := metadata.RequiredLanguageSet()
+	builder.AppendLines(fmt.Sprintf("VERSION = %s", stringLiteral(metadata.Version)), "")
+	builder.AppendLines("LANGUAGES: Dict[str, bool] = {")
+	builder.Indent()
+	for _, language := range metadata.SupportedLanguages {
+		_, required := requiredLangs[language]
+		value := "False"
+		if required {
+			value = "True"
+		}
+		builder.AppendLines(fmt.Sprintf("%s: %s,", stringLiteral(language), value))
+	}
+	builder.Unindent()
+	builder.AppendLines("}")
+
+	for _, lang := range metadata.SupportedLanguages {
+		builder.AppendLines("", "")
+		builder.AppendBlock(p.writePluralSelectorImpl(lang, &metadata))
+	}
+	builder.Build(w)
+	return nil
+}
+
+func (p *pythonCodeBuilder) fileBuilder(now time.Time) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(
+		fmt.Sprintf("# Generated with donggu at %s", now.UTC().Format(time.RFC3339)),
+		"# AUTOGENERATED CODE. DO NOT EDIT.",
+	)
+	return builder
+}
+
+// writeNode writes the class of a node with its child properties,
+// and the entry methods written to memberBuilder since the last node.
+// Members of the root node are written to DictionaryRoot, which is the base class of Donggu.
+func (p *pythonCodeBuilder) writeNode(key dictionary.EntryKey, childNames []string, isRoot bool) {
+	members := code.IndentedCodeBuilder{}
+	selfType, cb := "self", "self._cb"
+	if isRoot {
+		selfType, cb = `self: "Donggu"`, "self"
+	} else {
+		members.AppendLines(`_cb: "Donggu"`)
+	}
+	for _, child := range childNames {
+		childClass := nodeClassName(key.NewChild(child))
+		if len(members.Commands) > 0 {
+			members.AppendLines("")
+		}
+		members.AppendLines("@property", fmt.Sprintf("def %s(%s) -> %s:", nodePropertyName(child), selfType, childClass))
+		members.IndentedLines(fmt.Sprintf("return %s(%s)", childClass, cb))
+	}
+	if len(members.Commands) > 0 && len(p.memberBuilder.Commands) > 0 {
+		members.AppendLines("")
+	}
+	members.AppendBlock(p.memberBuilder)
+	p.memberBuilder = code.IndentedCodeBuilder{}
+	if len(members.Commands) == 0 {
+		members.AppendLines("pass")
+	}
+
+	p.nodeBuilder.AppendLines("")
+	if isRoot {
+		p.nodeBuilder.AppendLines("class DictionaryRoot:")
+	} else {
+		p.nodeBuilder.AppendLines("@dataclass(frozen=True)", fmt.Sprintf("class %s:", nodeClassName(key)))
+	}
+	p.nodeBuilder.IndentedBlock(members)
+	p.nodeBuilder.AppendLines("")
+}
+
+// writeEntryMethod writes the method of an entry to memberBuilder.
+// Self entries are written as __call__ of their node.
+func (p *pythonCodeBuilder) writeEntryMethod(key dictionary.EntryKey, entry dictionary.Entry, params []pythonParameter, isSelfEntry, isRoot bool) {
+	if len(p.memberBuilder.Commands) > 0 {
+		p.memberBuilder.AppendLines("")
+	}
+
+	declarations := []string{"self"}
+	names := make([]string, 0, len(params))
+	if isRoot {
+		declarations[0] = `self: "Donggu"`
+	}
+	for _, param := range params {
+		declarations = append(declarations, param.name+": "+param.typeName)
+		names = append(names, param.name)
+	}
+	methodName := entryMethodName(key)
+	resolver := "self._cb._resolve"
+	if isSelfEntry {
+		methodName = "__call__"
+	}
+	if isRoot {
+		resolver = "self._resolve"
+	}
+
+	p.memberBuilder.AppendLines(fmt.Sprintf("def %s(%s) -> str:", methodName, strings.Join(declarations, ", ")))
+	p.memberBuilder.Indent()
+	p.memberBuilder.AppendLines(fmt.Sprintf(`"""Text of entry %s.`, docText(fmt.Sprintf("`%s`", key))))
+	if context := entry["context"]; context != "" {
+		p.memberBuilder.AppendLines("", docText(context))
+	}
+	p.memberBuilder.AppendLines("")
+	for _, lang := range sortedLanguages(entry) {
+		p.memberBuilder.AppendLines(fmt.Sprintf("- `%s`: `%s`", lang, docText(entry[lang])))
+	}
+	p.memberBuilder.AppendLines(`"""`)
+	p.memberBuilder.AppendLines(fmt.Sprintf(
+		"return %s(%s, data.%s)(%s)",
+		resolver, stringLiteral(string(key)), entryFormattersName(key), strings.Join(names, ", "),
+	))
+	p.memberBuilder.Unindent()
+}
+
+// writeEntryFormatters writes the dictionary of formatter lambdas of an entry, keyed by language.
+func (p *pythonCodeBuilder) writeEntryFormatters(key dictionary.EntryKey, params []pythonParameter, impls map[string]string) {
+	paramTypes := make([]string, 0, len(params))
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		paramTypes = append(paramTypes, param.typeName)
+		names = append(names, param.name)
+	}
+	lambda := "lambda"
+	if len(names) > 0 {
+		lambda += " " + strings.Join(names, ", ")
+	}
+
+	p.dataBuilder.AppendLines(fmt.Sprintf(
+		"%s: Dict[str, Callable[[%s], str]] = {",
+		entryFormattersName(key), strings.Join(paramTypes, ", "),
+	))
+	p.dataBuilder.Indent()
+	languages := make([]string, 0, len(impls))
+	for lang := range impls {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	for _, lang := range languages {
+		p.dataBuilder.AppendLines(fmt.Sprintf("%s: %s: %s,", stringLiteral(lang), lambda, impls[lang]))
+	}
+	p.dataBuilder.Unindent()
+	p.dataBuilder.AppendLines("}", "")
+}
+
+func (p *pythonCodeBuilder) writePluralSelectorImpl(language string, metadata *dictionary.Metadata) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(fmt.Sprintf("def %s(value: int) -> str:", pluralSelectorFnName(language)))
+	builder.Indent()
+
+	if rules, ok := metadata.PluralRules(language); ok {
+		p.writeCldrPluralSelectorBody(&builder, rules)
+	} else {
+		defs, defsOk := metadata.Plurals[language]
+		if !defsOk {
+			defs = dictionary.DefaultPluralDefinition()
+		}
+		for index, def := range defs {
+			builder.AppendLines(fmt.Sprintf("if %s:", pythonOperatorReplacer.Replace(def.IntegerExpression("value"))))
+			builder.IndentedLines(fmt.Sprintf("return %s", stringLiteral(string(def.CategoryName(index)))))
+		}
+		builder.AppendLines(fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+
+	builder.Unindent()
+	return builder
+}
+
+// writeCldrPluralSelectorBody writes a plural selector from CLDR plural rules.
+// Plural values are always integers, so relations on fraction operands are evaluated in advance.
+func (p *pythonCodeBuilder) writeCldrPluralSelectorBody(builder *code.IndentedCodeBuilder, rules []dictionary.PluralRule) {
+	body := code.IndentedCodeBuilder{}
+	usesOperand := false
+	alwaysMatched := false
+	for _, rule := range rules {
+		condition := rule.Condition.IntegerOnly()
+		if len(condition) == 0 {
+			continue
+		}
+		if len(condition[0]) == 0 {
+			body.AppendLines(fmt.Sprintf("return %s", stringLiteral(string(rule.Category))))
+			alwaysMatched = true
+			break
+		}
+		usesOperand = true
+		body.AppendLines(fmt.Sprintf("if %s:", pythonOperatorReplacer.Replace(condition.IntegerExpression("n"))))
+		body.IndentedLines(fmt.Sprintf("return %s", stringLiteral(string(rule.Category))))
+	}
+	if !alwaysMatched {
+		body.AppendLines(fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+	if usesOperand {
+		builder.AppendLines("n = abs(value)")
+	}
+	builder.AppendBlock(body)
+}
+
+// docText converts a text to be used in a single line of a docstring.
+func docText(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, "\n", `\n`)
+	return strings.ReplaceAll(text, `"""`, `\"\"\"`)
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	languages := make([]string, 0, len(entry))
+	for lang := range entry {
+		if !dictionary.IsEntryMetaField(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
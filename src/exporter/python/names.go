@@ -0,0 +1,46 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+var pythonKeywords = map[string]struct{}{
+	"and": {}, "as": {}, "assert": {}, "async": {}, "await": {}, "break": {}, "class": {}, "continue": {},
+	"def": {}, "del": {}, "elif": {}, "else": {}, "except": {}, "finally": {}, "for": {}, "from": {},
+	"global": {}, "if": {}, "import": {}, "in": {}, "is": {}, "lambda": {}, "nonlocal": {}, "not": {},
+	"or": {}, "pass": {}, "raise": {}, "return": {}, "try": {}, "while": {}, "with": {}, "yield": {},
+}
+
+// identifier appends an underscore to names which are Python keywords, following PEP 8.
+func identifier(name string) string {
+	if _, ok := pythonKeywords[name]; ok {
+		return name + "_"
+	}
+	return name
+}
+
+func nodeClassName(key dictionary.EntryKey) string {
+	return "D_" + key.PascalCase()
+}
+
+func nodePropertyName(child string) string {
+	return identifier(child)
+}
+
+func entryMethodName(key dictionary.EntryKey) string {
+	return identifier(key.LastPart())
+}
+
+func argumentName(templateKey string) string {
+	return identifier(strings.ToLower(templateKey))
+}
+
+func entryFormattersName(key dictionary.EntryKey) string {
+	return "d_" + key.PascalCase() + "_Fmt"
+}
+
+func pluralSelectorFnName(language string) string {
+	return "l_plural_" + strings.ReplaceAll(language, "-", "_")
+}
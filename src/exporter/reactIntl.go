@@ -0,0 +1,53 @@
+package exporter
+
+import (
+	"io"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/icu"
+	"github.com/pkg/errors"
+)
+
+// ReactIntlDictionaryExporter writes messages for FormatJS and react-intl (https://formatjs.io).
+// Texts are converted to ICU MessageFormat, in the same way as IcuDictionaryExporter.
+//
+// ExportContent writes the messages of all languages keyed by language, and Export writes a file for each language.
+//
+// Options:
+//   - nested: write messages nested by the parts of entry keys. Defaults to false,
+//     as react-intl looks up messages by flat ids.
+type ReactIntlDictionaryExporter struct{}
+
+func (r ReactIntlDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessageFiles(projectRoot, content, metadata, libraryNestedOption(options, false), r.formatMessages)
+}
+
+func (r ReactIntlDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessages(file, content, metadata, libraryNestedOption(options, false), r.formatMessages)
+}
+
+func (r ReactIntlDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (r ReactIntlDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return validateNestedOption(options)
+}
+
+func (r ReactIntlDictionaryExporter) formatMessages(entry dictionary.Entry, lang string, metadata dictionary.Metadata) ([]libraryMessage, error) {
+	message, err := icu.FormatMessage(entry, lang, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return []libraryMessage{{Text: message}}, nil
+}
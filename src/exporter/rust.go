@@ -0,0 +1,74 @@
+package exporter
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter/rust"
+	"github.com/maasasia/donggu/util"
+	"github.com/pkg/errors"
+)
+
+// RustDictionaryExporter is a DictionaryProjectExporter
+// generating a Rust library crate for using the dictionary.
+//
+// The name of the crate is given by the option 'packageName'.
+type RustDictionaryExporter struct{}
+
+var rustCrateNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func (r RustDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	if err := r.prepareProject(projectRoot, options["packageName"].(string)); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+
+	builder := rust.NewRustBuilder(metadata)
+	if err := builder.Run(content.ToTree()); err != nil {
+		return err
+	}
+
+	return builder.Build(metadata, projectRoot)
+}
+
+func (r RustDictionaryExporter) prepareProject(projectRoot, packageName string) error {
+	if err := os.RemoveAll(projectRoot); err != nil {
+		return err
+	}
+	if err := code.CopyTemplateTo("rust", projectRoot, code.CopyTemplateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+
+	packageRenameErr := util.BatchReplaceFiles(
+		[]string{path.Join(projectRoot, "Cargo.toml")},
+		"donggu-template",
+		packageName,
+	)
+	if packageRenameErr != nil {
+		return errors.Wrap(packageRenameErr, "failed to write package names")
+	}
+	return nil
+}
+
+func (r RustDictionaryExporter) ValidateOptions(options OptionMap) error {
+	convOpts := map[string]interface{}(options)
+	if packageName, err := util.SafeAccessMap[string](&convOpts, "packageName"); err == nil {
+		if strings.TrimSpace(packageName) == "" {
+			return errors.New("package name (key 'packageName') should not be empty")
+		}
+		if !rustCrateNameRegex.MatchString(packageName) {
+			return errors.Errorf("package name (key 'packageName') '%s' is not a valid crate name", packageName)
+		}
+	} else {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package rust
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var rustStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+)
+
+// rustFormatEscaper escapes text to be used in the format string of format!.
+var rustFormatEscaper = strings.NewReplacer("{", "{{", "}", "}}")
+
+// stringLiteral returns text as a Rust string literal.
+func stringLiteral(text string) string {
+	return `"` + rustStringEscaper.Replace(text) + `"`
+}
+
+// rustFormatArgument is a placeholder of a format string, and the argument written to it.
+type rustFormatArgument struct {
+	placeholder string
+	expression  string
+}
+
+type rustArgumentFormatter struct {
+	metadata *dictionary.Metadata
+}
+
+func (r rustArgumentFormatter) ArgumentType(argType dictionary.TemplateKeyFormat) string {
+	switch argType.Kind {
+	case dictionary.IntTemplateKeyType:
+		return "i64"
+	case dictionary.FloatTemplateKeyType:
+		return "f64"
+	case dictionary.BoolTemplateKeyType:
+		return "bool"
+	case dictionary.PluralTemplateKeyType:
+		return "i64"
+	default:
+		return "&str"
+	}
+}
+
+// Format returns a placeholder of format! and its argument for a template.
+func (r rustArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (rustFormatArgument, error) {
+	name := argumentName(key)
+	switch format.Kind {
+	case dictionary.IntTemplateKeyType:
+		return r.formatNumeric(name, false, format), nil
+	case dictionary.FloatTemplateKeyType:
+		return r.formatNumeric(name, true, format), nil
+	case dictionary.BoolTemplateKeyType:
+		return r.formatBool(name, format), nil
+	case dictionary.PluralTemplateKeyType:
+		return r.formatPlural(language, name, format)
+	default:
+		return rustFormatArgument{"{}", name}, nil
+	}
+}
+
+func (r rustArgumentFormatter) formatPlural(language, name string, format dictionary.TemplateKeyFormat) (rustFormatArgument, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(r.metadata.PluralCategories(language))
+	if err != nil {
+		return rustFormatArgument{}, errors.Wrap(err, "invalid plural choices")
+	}
+	categories := make([]string, 0, len(choices))
+	for category := range choices {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	pairs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		pairs = append(pairs, fmt.Sprintf("(%s, %s)", stringLiteral(category), stringLiteral(choices[dictionary.PluralCategory(category)])))
+	}
+	return rustFormatArgument{"{}", fmt.Sprintf(
+		"crate::select_plural_choice(super::language::%s(%s), &[%s])",
+		pluralSelectorFnName(language), name, strings.Join(pairs, ", "),
+	)}, nil
+}
+
+func (r rustArgumentFormatter) formatBool(name string, format dictionary.TemplateKeyFormat) rustFormatArgument {
+	option := format.Option.(dictionary.BoolTemplateFormatOption)
+	if option.UseLocaleValues {
+		return rustFormatArgument{"{}", name}
+	}
+	return rustFormatArgument{"{}", fmt.Sprintf(
+		"crate::print_boolean_value(%s, %s, %s)",
+		name, stringLiteral(option.TrueValue), stringLiteral(option.FalseValue),
+	)}
+}
+
+// formatNumeric formats a number with the format spec of format!.
+// Floats are printed with 6 digits of precision by default, as printf does.
+func (r rustArgumentFormatter) formatNumeric(name string, isFloat bool, format dictionary.TemplateKeyFormat) rustFormatArgument {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	if option.IsZero() && !isFloat {
+		return rustFormatArgument{"{}", name}
+	}
+	spec := ""
+	if option.AlwaysAddSign {
+		spec += "+"
+	}
+	// Precision is not allowed for integers.
+	precision := ""
+	if isFloat {
+		if option.PrecisionSet {
+			precision = fmt.Sprintf(".%d", option.Precision)
+		} else {
+			precision = ".6"
+		}
+	}
+
+	// format! has no flag for comma separators, so they are inserted by format_grouped
+	// before the number is padded. Zeros are padded with separators, as Python does.
+	if option.CommaSeparator {
+		width := 0
+		if option.WidthSet {
+			width = option.Width
+		}
+		placeholder := "{}"
+		if spec+precision != "" {
+			placeholder = "{:" + spec + precision + "}"
+		}
+		return rustFormatArgument{"{}", fmt.Sprintf(
+			`crate::format_grouped(format!("%s", %s), %d, %t)`,
+			placeholder, name, width, option.PadCharacter == "0",
+		)}
+	}
+	if option.WidthSet {
+		if option.PadCharacter == "0" {
+			spec += "0"
+		}
+		spec += fmt.Sprintf("%d", option.Width)
+	}
+	return rustFormatArgument{"{:" + spec + precision + "}", name}
+}
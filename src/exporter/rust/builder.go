@@ -0,0 +1,206 @@
+package rust
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+type RustBuilder struct {
+	builder          *rustCodeBuilder
+	metadata         *dictionary.Metadata
+	contentValidator dictionary.ContentValidator
+}
+
+func NewRustBuilder(metadata dictionary.Metadata) *RustBuilder {
+	return &RustBuilder{
+		builder:  newRustCodeBuilder(),
+		metadata: &metadata,
+		contentValidator: dictionary.NewContentValidator(metadata, dictionary.ContentValidationOptions{
+			SkipLangSupportCheck: true,
+		}),
+	}
+}
+
+func (r *RustBuilder) Build(metadata dictionary.Metadata, projectRoot string) error {
+	now := time.Now()
+
+	operations := map[string]func(f *os.File) error{
+		"data.rs": func(f *os.File) error {
+			return r.builder.outputDataFile(f, now)
+		},
+		"language.rs": func(f *os.File) error {
+			return r.builder.outputLanguageFile(f, metadata, now)
+		},
+		"nodes.rs": func(f *os.File) error {
+			return r.builder.outputNodeFile(f, now)
+		},
+	}
+
+	for filename, saveFile := range operations {
+		file, err := r.openFile(projectRoot, filename)
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+		err = saveFile(file)
+		file.Close()
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+	}
+	return nil
+}
+
+func (r *RustBuilder) openFile(projectRoot, filename string) (*os.File, error) {
+	f, err := os.OpenFile(
+		path.Join(projectRoot, "src", "generated", filename),
+		os.O_CREATE|os.O_TRUNC|os.O_RDWR,
+		os.ModePerm,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open '%s'", filename)
+	}
+	return f, err
+}
+
+func (r *RustBuilder) Run(content *dictionary.ContentNode) error {
+	return r.walk(content, dictionary.EntryKey(""), nil, 0)
+}
+
+func (r *RustBuilder) walk(
+	contentNode *dictionary.ContentNode,
+	positionKey dictionary.EntryKey,
+	selfNameEntry dictionary.Entry,
+	depth int,
+) error {
+	childNames := make([]string, 0, len(contentNode.Children))
+
+	entriesToSkip := map[string]struct{}{}
+
+	for _, key := range sortedKeys(contentNode.Children) {
+		child := contentNode.Children[key]
+		childNames = append(childNames, key)
+
+		var err error
+		if _, ok := contentNode.Entries[key]; ok {
+			entriesToSkip[key] = struct{}{}
+			err = r.walk(child, positionKey.NewChild(key), contentNode.Entries[key], depth+1)
+		} else {
+			err = r.walk(child, positionKey.NewChild(key), nil, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if selfNameEntry != nil {
+		if _, ok := contentNode.Entries[selfEntryMethodName]; ok {
+			return errors.Errorf("entry '%s' conflicts with the method of entry '%s'", positionKey.NewChild(selfEntryMethodName), positionKey)
+		}
+		if _, ok := contentNode.Children[selfEntryMethodName]; ok {
+			return errors.Errorf("node '%s' conflicts with the method of entry '%s'", positionKey.NewChild(selfEntryMethodName), positionKey)
+		}
+		err := r.addEntry(selfNameEntry, positionKey, true, false)
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(contentNode.Entries) {
+		if _, ok := entriesToSkip[key]; ok {
+			continue
+		}
+		err := r.addEntry(contentNode.Entries[key], positionKey.NewChild(key), false, depth == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.builder.writeNode(positionKey, childNames, depth == 0)
+	return nil
+}
+
+func (r *RustBuilder) addEntry(entry dictionary.Entry, entryKey dictionary.EntryKey, isSelfEntry, isRoot bool) error {
+	templateKeys, validateErr := r.contentValidator.Validate(entry)
+	if validateErr != nil {
+		return errors.Wrapf(validateErr, "failed to add leaf '%s'", entryKey)
+	}
+	formatter := rustArgumentFormatter{metadata: r.metadata}
+
+	params := make([]rustParameter, 0, len(templateKeys))
+	for _, key := range sortedKeys(templateKeys) {
+		params = append(params, rustParameter{
+			name:     argumentName(key),
+			typeName: formatter.ArgumentType(templateKeys[key]),
+		})
+	}
+
+	impls := map[string]rustFormatterImpl{}
+	for lang := range entry {
+		if dictionary.IsEntryMetaField(lang) {
+			continue
+		}
+		impl, err := r.buildFormatterImpl(entry, lang, templateKeys, formatter)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build formatter value of '%s'", entryKey)
+		}
+		impls[lang] = impl
+	}
+
+	r.builder.writeEntryMethod(entryKey, entry, params, isSelfEntry, isRoot)
+	r.builder.writeEntryFormatters(entryKey, params, impls)
+	return nil
+}
+
+// buildFormatterImpl returns the body of the formatter function of an entry,
+// which writes the literals and template values of the text with format!.
+func (r *RustBuilder) buildFormatterImpl(
+	entry dictionary.Entry,
+	lang string,
+	argTypes map[string]dictionary.TemplateKeyFormat,
+	formatter rustArgumentFormatter,
+) (rustFormatterImpl, error) {
+	impl := rustFormatterImpl{usedParams: map[string]struct{}{}}
+	text, formatString := strings.Builder{}, strings.Builder{}
+	arguments := []string{}
+	escapeFn := func(literal string) string {
+		text.WriteString(literal)
+		formatString.WriteString(rustFormatEscaper.Replace(literal))
+		return ""
+	}
+	_, err := entry.ReplacedTemplateValueEscaped(lang, escapeFn, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		if _, ok := argTypes[key]; !ok {
+			return "", errors.Errorf("unknown template key '%s'", key)
+		}
+		argument, formatErr := formatter.Format(lang, key, format)
+		if formatErr != nil {
+			return "", errors.Wrapf(formatErr, "failed to format template '%s'", key)
+		}
+		formatString.WriteString(argument.placeholder)
+		arguments = append(arguments, argument.expression)
+		impl.usedParams[argumentName(key)] = struct{}{}
+		return "", nil
+	})
+	if err != nil {
+		return impl, errors.Wrap(err, "failed to parse template parameter")
+	}
+	if len(arguments) == 0 {
+		impl.body = fmt.Sprintf("String::from(%s)", stringLiteral(text.String()))
+		return impl, nil
+	}
+	impl.body = fmt.Sprintf("format!(%s, %s)", stringLiteral(formatString.String()), strings.Join(arguments, ", "))
+	return impl, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,276 @@
+package rust
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// selfEntryMethodName is the method name of an entry with the same key as a node.
+const selfEntryMethodName = "text"
+
+type rustParameter struct {
+	name     string
+	typeName string
+}
+
+// rustFormatterImpl is the body of the formatter function of an entry in a language.
+type rustFormatterImpl struct {
+	body       string
+	usedParams map[string]struct{}
+}
+
+type rustCodeBuilder struct {
+	nodeBuilder code.IndentedCodeBuilder
+	dataBuilder code.IndentedCodeBuilder
+	// memberBuilder holds the entry methods of the node being walked,
+	// until they are written with the node.
+	memberBuilder code.IndentedCodeBuilder
+}
+
+func newRustCodeBuilder() *rustCodeBuilder {
+	return &rustCodeBuilder{}
+}
+
+func (r *rustCodeBuilder) outputNodeFile(w io.Writer, now time.Time) error {
+	builder := r.fileBuilder(now)
+	builder.AppendLines("use super::data;", "use crate::Donggu;", "")
+	builder.AppendBlock(r.nodeBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (r *rustCodeBuilder) outputDataFile(w io.Writer, now time.Time) error {
+	builder := r.fileBuilder(now)
+	builder.AppendBlock(r.dataBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (r *rustCodeBuilder) outputLanguageFile(w io.Writer, metadata dictionary.Metadata, now time.Time) error {
+	builder := r.fileBuilder(now)
+
+	requiredLangs := metadata.RequiredLanguageSet()
+	builder.AppendLines(fmt.Sprintf("pub(crate) const VERSION: &str = %s;", stringLiteral(metadata.Version)), "")
+	builder.AppendLines("pub(crate) const LANGUAGES: &[(&str, bool)] = &[")
+	builder.Indent()
+	for _, language := range metadata.SupportedLanguages {
+		_, required := requiredLangs[language]
+		builder.AppendLines(fmt.Sprintf("(%s, %t),", stringLiteral(language), required))
+	}
+	builder.Unindent()
+	builder.AppendLines("];")
+
+	for _, lang := range metadata.SupportedLanguages {
+		builder.AppendLines("")
+		builder.AppendBlock(r.writePluralSelectorImpl(lang, &metadata))
+	}
+	builder.Build(w)
+	return nil
+}
+
+func (r *rustCodeBuilder) fileBuilder(now time.Time) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(
+		fmt.Sprintf("// Generated with donggu at %s", now.UTC().Format(time.RFC3339)),
+		"// AUTOGENERATED CODE. DO NOT EDIT.",
+		"#![allow(dead_code, unused_parens, non_snake_case, non_camel_case_types, non_upper_case_globals, unused_imports, unused_variables)]",
+		"",
+	)
+	return builder
+}
+
+// writeNode writes the struct of a node with its child methods,
+// and the entry methods written to memberBuilder since the last node.
+// Members of the root node are written as methods of the Donggu struct.
+func (r *rustCodeBuilder) writeNode(key dictionary.EntryKey, childNames []string, isRoot bool) {
+	members := code.IndentedCodeBuilder{}
+	cb, lifetime := "self.cb", "'a"
+	if isRoot {
+		cb, lifetime = "self", "'_"
+	}
+	for _, child := range childNames {
+		childStruct := nodeStructName(key.NewChild(child))
+		if len(members.Commands) > 0 {
+			members.AppendLines("")
+		}
+		members.AppendLines(fmt.Sprintf("pub fn %s(&self) -> %s<%s> {", nodeMethodName(child), childStruct, lifetime))
+		members.IndentedLines(fmt.Sprintf("%s { cb: %s }", childStruct, cb))
+		members.AppendLines("}")
+	}
+	if len(members.Commands) > 0 && len(r.memberBuilder.Commands) > 0 {
+		members.AppendLines("")
+	}
+	members.AppendBlock(r.memberBuilder)
+	r.memberBuilder = code.IndentedCodeBuilder{}
+
+	if isRoot {
+		if len(members.Commands) > 0 {
+			r.nodeBuilder.AppendLines("impl Donggu {")
+			r.nodeBuilder.IndentedBlock(members)
+			r.nodeBuilder.AppendLines("}", "")
+		}
+		return
+	}
+	structName := nodeStructName(key)
+	r.nodeBuilder.AppendLines(fmt.Sprintf("pub struct %s<'a> {", structName))
+	r.nodeBuilder.IndentedLines("cb: &'a Donggu,")
+	r.nodeBuilder.AppendLines("}", "")
+	if len(members.Commands) > 0 {
+		r.nodeBuilder.AppendLines(fmt.Sprintf("impl<'a> %s<'a> {", structName))
+		r.nodeBuilder.IndentedBlock(members)
+		r.nodeBuilder.AppendLines("}", "")
+	}
+}
+
+// writeEntryMethod writes the method of an entry to memberBuilder.
+// Self entries are written as the method `text` of their node.
+func (r *rustCodeBuilder) writeEntryMethod(key dictionary.EntryKey, entry dictionary.Entry, params []rustParameter, isSelfEntry, isRoot bool) {
+	if len(r.memberBuilder.Commands) > 0 {
+		r.memberBuilder.AppendLines("")
+	}
+	r.memberBuilder.AppendLines(fmt.Sprintf("/// Text of entry `%s`.", key))
+	if context := entry["context"]; context != "" {
+		r.memberBuilder.AppendLines("///", "/// "+docText(context))
+	}
+	r.memberBuilder.AppendLines("///")
+	for _, lang := range sortedLanguages(entry) {
+		r.memberBuilder.AppendLines(fmt.Sprintf("/// - `%s`: `%s`", lang, docText(entry[lang])))
+	}
+
+	declarations := []string{"&self"}
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		declarations = append(declarations, param.name+": "+param.typeName)
+		names = append(names, param.name)
+	}
+
+	methodName := entryMethodName(key)
+	resolver := "self.cb.resolve"
+	if isSelfEntry {
+		methodName = selfEntryMethodName
+	}
+	if isRoot {
+		resolver = "self.resolve"
+	}
+	r.memberBuilder.AppendLines(fmt.Sprintf("pub fn %s(%s) -> String {", methodName, strings.Join(declarations, ", ")))
+	r.memberBuilder.IndentedLines(fmt.Sprintf(
+		"%s(%s, data::%s)(%s)",
+		resolver, stringLiteral(string(key)), entryFormattersName(key), strings.Join(names, ", "),
+	))
+	r.memberBuilder.AppendLines("}")
+}
+
+// writeEntryFormatters writes the formatter functions of an entry,
+// and the table of the functions keyed by language.
+func (r *rustCodeBuilder) writeEntryFormatters(key dictionary.EntryKey, params []rustParameter, impls map[string]rustFormatterImpl) {
+	paramTypes := make([]string, 0, len(params))
+	for _, param := range params {
+		paramTypes = append(paramTypes, param.typeName)
+	}
+
+	languages := make([]string, 0, len(impls))
+	for lang := range impls {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+
+	for _, lang := range languages {
+		impl := impls[lang]
+		declarations := make([]string, 0, len(params))
+		for _, param := range params {
+			name := param.name
+			if _, ok := impl.usedParams[name]; !ok {
+				name = "_" + strings.TrimPrefix(name, "r#")
+			}
+			declarations = append(declarations, name+": "+param.typeName)
+		}
+		r.dataBuilder.AppendLines(fmt.Sprintf("fn %s(%s) -> String {", entryFormatFnName(key, lang), strings.Join(declarations, ", ")))
+		r.dataBuilder.IndentedLines(impl.body)
+		r.dataBuilder.AppendLines("}", "")
+	}
+
+	r.dataBuilder.AppendLines(fmt.Sprintf(
+		"pub(crate) const %s: &[(&str, fn(%s) -> String)] = &[",
+		entryFormattersName(key), strings.Join(paramTypes, ", "),
+	))
+	r.dataBuilder.Indent()
+	for _, lang := range languages {
+		r.dataBuilder.AppendLines(fmt.Sprintf("(%s, %s),", stringLiteral(lang), entryFormatFnName(key, lang)))
+	}
+	r.dataBuilder.Unindent()
+	r.dataBuilder.AppendLines("];", "")
+}
+
+func (r *rustCodeBuilder) writePluralSelectorImpl(language string, metadata *dictionary.Metadata) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(fmt.Sprintf("pub(crate) fn %s(value: i64) -> &'static str {", pluralSelectorFnName(language)))
+	builder.Indent()
+
+	if rules, ok := metadata.PluralRules(language); ok {
+		r.writeCldrPluralSelectorBody(&builder, rules)
+	} else {
+		defs, defsOk := metadata.Plurals[language]
+		if !defsOk {
+			defs = dictionary.DefaultPluralDefinition()
+		}
+		for index, def := range defs {
+			builder.AppendLines(fmt.Sprintf("if %s {", def.IntegerExpression("value")))
+			builder.IndentedLines(fmt.Sprintf("return %s;", stringLiteral(string(def.CategoryName(index)))))
+			builder.AppendLines("}")
+		}
+		builder.AppendLines(stringLiteral(string(dictionary.OtherPluralCategory)))
+	}
+
+	builder.Unindent()
+	builder.AppendLines("}")
+	return builder
+}
+
+// writeCldrPluralSelectorBody writes a plural selector from CLDR plural rules.
+// Plural values are always integers, so relations on fraction operands are evaluated in advance.
+func (r *rustCodeBuilder) writeCldrPluralSelectorBody(builder *code.IndentedCodeBuilder, rules []dictionary.PluralRule) {
+	body := code.IndentedCodeBuilder{}
+	usesOperand := false
+	result := stringLiteral(string(dictionary.OtherPluralCategory))
+	for _, rule := range rules {
+		condition := rule.Condition.IntegerOnly()
+		if len(condition) == 0 {
+			continue
+		}
+		if len(condition[0]) == 0 {
+			result = stringLiteral(string(rule.Category))
+			break
+		}
+		usesOperand = true
+		body.AppendLines(fmt.Sprintf("if %s {", condition.IntegerExpression("n")))
+		body.IndentedLines(fmt.Sprintf("return %s;", stringLiteral(string(rule.Category))))
+		body.AppendLines("}")
+	}
+	if usesOperand {
+		builder.AppendLines("let n = value.abs();")
+	}
+	builder.AppendBlock(body)
+	builder.AppendLines(result)
+}
+
+// docText converts a text to be used in a single line of a documentation comment.
+func docText(text string) string {
+	return strings.ReplaceAll(text, "\n", `\n`)
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	languages := make([]string, 0, len(entry))
+	for lang := range entry {
+		if !dictionary.IsEntryMetaField(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,59 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+var rustKeywords = map[string]struct{}{
+	"as": {}, "break": {}, "const": {}, "continue": {}, "else": {}, "enum": {}, "extern": {}, "false": {},
+	"fn": {}, "for": {}, "if": {}, "impl": {}, "in": {}, "let": {}, "loop": {}, "match": {}, "mod": {},
+	"move": {}, "mut": {}, "pub": {}, "ref": {}, "return": {}, "static": {}, "struct": {}, "trait": {},
+	"true": {}, "type": {}, "unsafe": {}, "use": {}, "where": {}, "while": {}, "async": {}, "await": {},
+	"dyn": {}, "abstract": {}, "become": {}, "box": {}, "do": {}, "final": {}, "macro": {}, "override": {},
+	"priv": {}, "typeof": {}, "unsized": {}, "virtual": {}, "yield": {}, "try": {},
+}
+
+// rustReservedIdentifiers are keywords which cannot be used as raw identifiers.
+var rustReservedIdentifiers = map[string]struct{}{
+	"crate": {}, "self": {}, "super": {},
+}
+
+func identifier(name string) string {
+	if _, ok := rustKeywords[name]; ok {
+		return "r#" + name
+	}
+	if _, ok := rustReservedIdentifiers[name]; ok {
+		return name + "_"
+	}
+	return name
+}
+
+func nodeStructName(key dictionary.EntryKey) string {
+	return "D_" + key.PascalCase()
+}
+
+func nodeMethodName(child string) string {
+	return identifier(child)
+}
+
+func entryMethodName(key dictionary.EntryKey) string {
+	return identifier(key.LastPart())
+}
+
+func argumentName(templateKey string) string {
+	return identifier(strings.ToLower(templateKey))
+}
+
+func entryFormattersName(key dictionary.EntryKey) string {
+	return "d_" + key.PascalCase() + "_Fmt"
+}
+
+func entryFormatFnName(key dictionary.EntryKey, locale string) string {
+	return "d_" + key.PascalCase() + "_Fmt_" + strings.ReplaceAll(locale, "-", "_")
+}
+
+func pluralSelectorFnName(language string) string {
+	return "l_plural_" + strings.ReplaceAll(language, "-", "_")
+}
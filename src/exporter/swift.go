@@ -0,0 +1,74 @@
+package exporter
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/exporter/swift"
+	"github.com/maasasia/donggu/util"
+	"github.com/pkg/errors"
+)
+
+// SwiftDictionaryExporter is a DictionaryProjectExporter
+// generating a Swift package for using the dictionary.
+//
+// The name of the package and its library target is given by the option 'packageName'.
+type SwiftDictionaryExporter struct{}
+
+var swiftPackageNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func (s SwiftDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	if err := s.prepareProject(projectRoot, options["packageName"].(string)); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+
+	builder := swift.NewSwiftBuilder(metadata)
+	if err := builder.Run(content.ToTree()); err != nil {
+		return err
+	}
+
+	return builder.Build(metadata, path.Join(projectRoot, "Sources"))
+}
+
+func (s SwiftDictionaryExporter) prepareProject(projectRoot, packageName string) error {
+	if err := os.RemoveAll(projectRoot); err != nil {
+		return err
+	}
+	if err := code.CopyTemplateTo("swift", projectRoot, code.CopyTemplateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to prepare project")
+	}
+	if err := os.Mkdir(path.Join(projectRoot, "Sources", "generated"), fs.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create generated folder")
+	}
+
+	packageRenameErr := util.BatchReplaceFiles(
+		[]string{path.Join(projectRoot, "Package.swift")},
+		"DongguTemplate",
+		packageName,
+	)
+	if packageRenameErr != nil {
+		return errors.Wrap(packageRenameErr, "failed to write package names")
+	}
+	return nil
+}
+
+func (s SwiftDictionaryExporter) ValidateOptions(options OptionMap) error {
+	convOpts := map[string]interface{}(options)
+	packageName, err := util.SafeAccessMap[string](&convOpts, "packageName")
+	if err != nil {
+		return err
+	}
+	if !swiftPackageNameRegex.MatchString(packageName) {
+		return errors.Errorf("package name (key 'packageName') '%s' should be a valid Swift identifier", packageName)
+	}
+	return nil
+}
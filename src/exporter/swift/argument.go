@@ -0,0 +1,109 @@
+package swift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var swiftStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+)
+
+// stringLiteral returns text as a Swift string literal.
+func stringLiteral(text string) string {
+	return `"` + swiftStringEscaper.Replace(text) + `"`
+}
+
+type swiftArgumentFormatter struct {
+	metadata *dictionary.Metadata
+	argTypes map[string]dictionary.TemplateKeyFormat
+}
+
+func (s swiftArgumentFormatter) ArgumentType(argType dictionary.TemplateKeyFormat) string {
+	switch argType.Kind {
+	case dictionary.IntTemplateKeyType:
+		return "Int"
+	case dictionary.FloatTemplateKeyType:
+		return "Double"
+	case dictionary.BoolTemplateKeyType:
+		return "Bool"
+	case dictionary.PluralTemplateKeyType:
+		return "Int"
+	default:
+		return "String"
+	}
+}
+
+// Format returns a string interpolation (ie. `\(name)`) for a template.
+func (s swiftArgumentFormatter) Format(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
+	name := argumentName(key)
+	switch format.Kind {
+	case dictionary.IntTemplateKeyType:
+		return s.formatNumeric(name, "ld", format), nil
+	case dictionary.FloatTemplateKeyType:
+		return s.formatNumeric(name, "f", format), nil
+	case dictionary.BoolTemplateKeyType:
+		return s.formatBool(name, format), nil
+	case dictionary.PluralTemplateKeyType:
+		return s.formatPlural(language, key, format)
+	default:
+		return `\(` + name + `)`, nil
+	}
+}
+
+func (s swiftArgumentFormatter) formatPlural(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(s.metadata.PluralCategories(language))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid plural choices")
+	}
+	categories := make([]string, 0, len(choices))
+	for category := range choices {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	pairs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", stringLiteral(category), stringLiteral(choices[dictionary.PluralCategory(category)])))
+	}
+	return fmt.Sprintf(`\(selectPluralChoice(%s(%s), [%s]))`, pluralSelectorFnName(language), argumentName(key), strings.Join(pairs, ", ")), nil
+}
+
+func (s swiftArgumentFormatter) formatBool(name string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.BoolTemplateFormatOption)
+	if option.UseLocaleValues {
+		return `\(` + name + `)`
+	}
+	return fmt.Sprintf(`\(printBooleanValue(%s, %s, %s))`, name, stringLiteral(option.TrueValue), stringLiteral(option.FalseValue))
+}
+
+func (s swiftArgumentFormatter) formatNumeric(name, conversion string, format dictionary.TemplateKeyFormat) string {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	if option.IsZero() && conversion == "ld" {
+		return `\(` + name + `)`
+	}
+	formatString := "%"
+	if option.AlwaysAddSign {
+		formatString += "+"
+	}
+	if option.PadCharacter == "0" {
+		formatString += "0"
+	}
+	if option.WidthSet {
+		formatString += fmt.Sprintf("%d", option.Width)
+	}
+	if option.PrecisionSet {
+		formatString += fmt.Sprintf(".%d", option.Precision)
+	}
+	formatString += conversion
+	// String(format:) has no flag for comma separators, so they are inserted by formatNumber.
+	if option.CommaSeparator {
+		return fmt.Sprintf(`\(formatNumber(%s, %s, grouping: true))`, stringLiteral(formatString), name)
+	}
+	return fmt.Sprintf(`\(formatNumber(%s, %s))`, stringLiteral(formatString), name)
+}
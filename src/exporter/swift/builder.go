@@ -0,0 +1,188 @@
+package swift
+
+import (
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+type SwiftBuilder struct {
+	builder          *swiftCodeBuilder
+	metadata         *dictionary.Metadata
+	contentValidator dictionary.ContentValidator
+}
+
+// swiftFormatterImpl is the formatter closure of an entry in a language.
+type swiftFormatterImpl struct {
+	body       string
+	usedParams map[string]struct{}
+}
+
+func NewSwiftBuilder(metadata dictionary.Metadata) *SwiftBuilder {
+	return &SwiftBuilder{
+		builder:  newSwiftCodeBuilder(),
+		metadata: &metadata,
+		contentValidator: dictionary.NewContentValidator(metadata, dictionary.ContentValidationOptions{
+			SkipLangSupportCheck: true,
+		}),
+	}
+}
+
+func (s *SwiftBuilder) Build(metadata dictionary.Metadata, projectRoot string) error {
+	now := time.Now()
+
+	operations := map[string]func(f *os.File) error{
+		"Data.swift": func(f *os.File) error {
+			return s.builder.outputDataFile(f, now)
+		},
+		"Language.swift": func(f *os.File) error {
+			return s.builder.outputLanguageFile(f, metadata, now)
+		},
+		"Nodes.swift": func(f *os.File) error {
+			return s.builder.outputNodeFile(f, now)
+		},
+	}
+
+	for filename, saveFile := range operations {
+		file, err := s.openFile(projectRoot, filename)
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+		err = saveFile(file)
+		file.Close()
+		if err != nil {
+			return errors.Wrap(err, "build failed")
+		}
+	}
+	return nil
+}
+
+func (s *SwiftBuilder) openFile(projectRoot, filename string) (*os.File, error) {
+	f, err := os.OpenFile(
+		path.Join(projectRoot, "generated", filename),
+		os.O_CREATE|os.O_TRUNC|os.O_RDWR,
+		os.ModePerm,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open '%s'", filename)
+	}
+	return f, err
+}
+
+func (s *SwiftBuilder) Run(content *dictionary.ContentNode) error {
+	return s.walk(content, dictionary.EntryKey(""), nil, 0)
+}
+
+func (s *SwiftBuilder) walk(
+	contentNode *dictionary.ContentNode,
+	positionKey dictionary.EntryKey,
+	selfNameEntry dictionary.Entry,
+	depth int,
+) error {
+	childNames := make([]string, 0, len(contentNode.Children))
+
+	entriesToSkip := map[string]struct{}{}
+
+	for _, key := range sortedKeys(contentNode.Children) {
+		child := contentNode.Children[key]
+		childNames = append(childNames, key)
+
+		var err error
+		if _, ok := contentNode.Entries[key]; ok {
+			entriesToSkip[key] = struct{}{}
+			err = s.walk(child, positionKey.NewChild(key), contentNode.Entries[key], depth+1)
+		} else {
+			err = s.walk(child, positionKey.NewChild(key), nil, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if selfNameEntry != nil {
+		err := s.addEntry(selfNameEntry, positionKey, true, false)
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(contentNode.Entries) {
+		if _, ok := entriesToSkip[key]; ok {
+			continue
+		}
+		err := s.addEntry(contentNode.Entries[key], positionKey.NewChild(key), false, depth == 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.builder.writeNode(positionKey, childNames, depth == 0)
+	return nil
+}
+
+func (s *SwiftBuilder) addEntry(entry dictionary.Entry, entryKey dictionary.EntryKey, isSelfEntry, isRoot bool) error {
+	templateKeys, validateErr := s.contentValidator.Validate(entry)
+	if validateErr != nil {
+		return errors.Wrapf(validateErr, "failed to add leaf '%s'", entryKey)
+	}
+	formatter := swiftArgumentFormatter{metadata: s.metadata, argTypes: templateKeys}
+
+	params := make([]swiftParameter, 0, len(templateKeys))
+	for _, key := range sortedKeys(templateKeys) {
+		params = append(params, swiftParameter{
+			name:     argumentName(key),
+			typeName: formatter.ArgumentType(templateKeys[key]),
+		})
+	}
+
+	impls := map[string]swiftFormatterImpl{}
+	for lang := range entry {
+		if dictionary.IsEntryMetaField(lang) {
+			continue
+		}
+		impl, err := s.buildFormatterImpl(entry, lang, formatter)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build formatter value of '%s'", entryKey)
+		}
+		impls[lang] = impl
+	}
+
+	s.builder.writeEntryMethod(entryKey, entry, params, isSelfEntry, isRoot)
+	s.builder.writeEntryFormatters(entryKey, params, impls)
+	return nil
+}
+
+func (s *SwiftBuilder) buildFormatterImpl(
+	entry dictionary.Entry,
+	lang string,
+	formatter swiftArgumentFormatter,
+) (swiftFormatterImpl, error) {
+	impl := swiftFormatterImpl{usedParams: map[string]struct{}{}}
+	templateString, err := entry.ReplacedTemplateValueEscaped(lang, swiftStringEscaper.Replace, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		if _, ok := formatter.argTypes[key]; !ok {
+			return "", errors.Errorf("unknown template key '%s'", key)
+		}
+		expression, formatErr := formatter.Format(lang, key, format)
+		if formatErr != nil {
+			return "", errors.Wrapf(formatErr, "failed to format template '%s'", key)
+		}
+		impl.usedParams[argumentName(key)] = struct{}{}
+		return expression, nil
+	})
+	if err != nil {
+		return impl, errors.Wrap(err, "failed to parse template parameter")
+	}
+	impl.body = `"` + templateString + `"`
+	return impl, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
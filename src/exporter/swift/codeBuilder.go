@@ -0,0 +1,251 @@
+package swift
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+type swiftParameter struct {
+	name     string
+	typeName string
+}
+
+type swiftCodeBuilder struct {
+	nodeBuilder code.IndentedCodeBuilder
+	dataBuilder code.IndentedCodeBuilder
+	// memberBuilder holds the entry methods of the node being walked,
+	// until they are written with the node.
+	memberBuilder code.IndentedCodeBuilder
+}
+
+func newSwiftCodeBuilder() *swiftCodeBuilder {
+	return &swiftCodeBuilder{}
+}
+
+func (s *swiftCodeBuilder) outputNodeFile(w io.Writer, now time.Time) error {
+	builder := s.fileBuilder(now)
+	builder.AppendBlock(s.nodeBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (s *swiftCodeBuilder) outputDataFile(w io.Writer, now time.Time) error {
+	builder := s.fileBuilder(now)
+	builder.AppendBlock(s.dataBuilder)
+	builder.Build(w)
+	return nil
+}
+
+func (s *swiftCodeBuilder) outputLanguageFile(w io.Writer, metadata dictionary.Metadata, now time.Time) error {
+	builder := s.fileBuilder(now)
+
+	requiredLangs := metadata.RequiredLanguageSet()
+	builder.AppendLines(fmt.Sprintf("let dictionaryVersion = %s", stringLiteral(metadata.Version)), "")
+	builder.AppendLines("let languages: [String: Bool] = [")
+	builder.Indent()
+	for _, language := range metadata.SupportedLanguages {
+		_, required := requiredLangs[language]
+		builder.AppendLines(fmt.Sprintf("%s: %t,", stringLiteral(language), required))
+	}
+	builder.Unindent()
+	builder.AppendLines("]")
+
+	for _, lang := range metadata.SupportedLanguages {
+		builder.AppendLines("")
+		builder.AppendBlock(s.writePluralSelectorImpl(lang, &metadata))
+	}
+	builder.Build(w)
+	return nil
+}
+
+func (s *swiftCodeBuilder) fileBuilder(now time.Time) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(
+		fmt.Sprintf("// Generated with donggu at %s", now.UTC().Format(time.RFC3339)),
+		"// AUTOGENERATED CODE. DO NOT EDIT.",
+		"",
+	)
+	return builder
+}
+
+// writeNode writes the struct of a node with its child properties,
+// and the entry methods written to memberBuilder since the last node.
+// Members of the root node are written as an extension of the Donggu struct.
+func (s *swiftCodeBuilder) writeNode(key dictionary.EntryKey, childNames []string, isRoot bool) {
+	members := code.IndentedCodeBuilder{}
+	cb := "_cb"
+	if isRoot {
+		cb = "self"
+	} else {
+		members.AppendLines("let _cb: Donggu", "")
+	}
+	for _, child := range childNames {
+		childStruct := nodeStructName(key.NewChild(child))
+		members.AppendLines(fmt.Sprintf("public var %s: %s { %s(_cb: %s) }", nodePropertyName(child), childStruct, childStruct, cb))
+	}
+	if len(childNames) > 0 && len(s.memberBuilder.Commands) > 0 {
+		members.AppendLines("")
+	}
+	members.AppendBlock(s.memberBuilder)
+	s.memberBuilder = code.IndentedCodeBuilder{}
+
+	if isRoot {
+		if len(members.Commands) > 0 {
+			s.nodeBuilder.AppendLines("extension Donggu {")
+			s.nodeBuilder.IndentedBlock(members)
+			s.nodeBuilder.AppendLines("}", "")
+		}
+		return
+	}
+	s.nodeBuilder.AppendLines(fmt.Sprintf("public struct %s {", nodeStructName(key)))
+	s.nodeBuilder.IndentedBlock(members)
+	s.nodeBuilder.AppendLines("}", "")
+}
+
+// writeEntryMethod writes the method of an entry to memberBuilder.
+// Self entries are written as callAsFunction of their node.
+func (s *swiftCodeBuilder) writeEntryMethod(key dictionary.EntryKey, entry dictionary.Entry, params []swiftParameter, isSelfEntry, isRoot bool) {
+	if len(s.memberBuilder.Commands) > 0 {
+		s.memberBuilder.AppendLines("")
+	}
+	s.memberBuilder.AppendLines(fmt.Sprintf("/// Text of entry `%s`.", key))
+	if context := entry["context"]; context != "" {
+		s.memberBuilder.AppendLines("///", "/// "+docText(context))
+	}
+	s.memberBuilder.AppendLines("///")
+	for _, lang := range sortedLanguages(entry) {
+		s.memberBuilder.AppendLines(fmt.Sprintf("/// - `%s`: `%s`", lang, docText(entry[lang])))
+	}
+
+	declarations := make([]string, 0, len(params))
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		declarations = append(declarations, param.name+": "+param.typeName)
+		names = append(names, param.name)
+	}
+
+	methodName := entryMethodName(key)
+	resolver := "_cb.resolve"
+	if isSelfEntry {
+		methodName = "callAsFunction"
+	}
+	if isRoot {
+		resolver = "resolve"
+	}
+	s.memberBuilder.AppendLines(fmt.Sprintf("public func %s(%s) -> String {", methodName, strings.Join(declarations, ", ")))
+	s.memberBuilder.IndentedLines(fmt.Sprintf(
+		"%s(%s, %s)(%s)",
+		resolver, stringLiteral(string(key)), entryFormattersName(key), strings.Join(names, ", "),
+	))
+	s.memberBuilder.AppendLines("}")
+}
+
+// writeEntryFormatters writes the dictionary of formatter closures of an entry, keyed by language.
+func (s *swiftCodeBuilder) writeEntryFormatters(key dictionary.EntryKey, params []swiftParameter, impls map[string]swiftFormatterImpl) {
+	paramTypes := make([]string, 0, len(params))
+	for _, param := range params {
+		paramTypes = append(paramTypes, param.typeName)
+	}
+	declaration := fmt.Sprintf("let %s: [String: (%s) -> String] =", entryFormattersName(key), strings.Join(paramTypes, ", "))
+	if len(impls) == 0 {
+		s.dataBuilder.AppendLines(declaration+" [:]", "")
+		return
+	}
+	s.dataBuilder.AppendLines(declaration + " [")
+	s.dataBuilder.Indent()
+
+	languages := make([]string, 0, len(impls))
+	for lang := range impls {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+	for _, lang := range languages {
+		impl := impls[lang]
+		if len(params) == 0 {
+			s.dataBuilder.AppendLines(fmt.Sprintf("%s: { %s },", stringLiteral(lang), impl.body))
+			continue
+		}
+		names := make([]string, 0, len(params))
+		for _, param := range params {
+			name := param.name
+			if _, ok := impl.usedParams[name]; !ok {
+				name = "_"
+			}
+			names = append(names, name)
+		}
+		s.dataBuilder.AppendLines(fmt.Sprintf("%s: { %s in %s },", stringLiteral(lang), strings.Join(names, ", "), impl.body))
+	}
+	s.dataBuilder.Unindent()
+	s.dataBuilder.AppendLines("]", "")
+}
+
+func (s *swiftCodeBuilder) writePluralSelectorImpl(language string, metadata *dictionary.Metadata) code.IndentedCodeBuilder {
+	builder := code.IndentedCodeBuilder{}
+	builder.AppendLines(fmt.Sprintf("func %s(_ value: Int) -> String {", pluralSelectorFnName(language)))
+	builder.Indent()
+
+	if rules, ok := metadata.PluralRules(language); ok {
+		s.writeCldrPluralSelectorBody(&builder, rules)
+	} else {
+		defs, defsOk := metadata.Plurals[language]
+		if !defsOk {
+			defs = dictionary.DefaultPluralDefinition()
+		}
+		for index, def := range defs {
+			builder.AppendLines(fmt.Sprintf("if %s { return %s }", def.IntegerExpression("value"), stringLiteral(string(def.CategoryName(index)))))
+		}
+		builder.AppendLines(fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+
+	builder.Unindent()
+	builder.AppendLines("}")
+	return builder
+}
+
+// writeCldrPluralSelectorBody writes a plural selector from CLDR plural rules.
+// Plural values are always integers, so relations on fraction operands are evaluated in advance.
+func (s *swiftCodeBuilder) writeCldrPluralSelectorBody(builder *code.IndentedCodeBuilder, rules []dictionary.PluralRule) {
+	lines := []string{}
+	alwaysMatched := false
+	for _, rule := range rules {
+		condition := rule.Condition.IntegerOnly()
+		if len(condition) == 0 {
+			continue
+		}
+		if len(condition[0]) == 0 {
+			lines = append(lines, fmt.Sprintf("return %s", stringLiteral(string(rule.Category))))
+			alwaysMatched = true
+			break
+		}
+		lines = append(lines, fmt.Sprintf("if %s { return %s }", condition.IntegerExpression("n"), stringLiteral(string(rule.Category))))
+	}
+	if !alwaysMatched {
+		lines = append(lines, fmt.Sprintf("return %s", stringLiteral(string(dictionary.OtherPluralCategory))))
+	}
+	if len(lines) > 1 {
+		builder.AppendLines("let n = value < 0 ? -value : value")
+	}
+	builder.AppendLines(lines...)
+}
+
+// docText converts a text to be used in a single line of a documentation comment.
+func docText(text string) string {
+	return strings.ReplaceAll(text, "\n", `\n`)
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	languages := make([]string, 0, len(entry))
+	for lang := range entry {
+		if !dictionary.IsEntryMetaField(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,51 @@
+package swift
+
+import (
+	"strings"
+
+	"github.com/maasasia/donggu/code"
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// swiftKeywords are keywords which cannot be used as identifiers without backticks.
+var swiftKeywords = map[string]struct{}{
+	"associatedtype": {}, "class": {}, "deinit": {}, "enum": {}, "extension": {}, "fileprivate": {},
+	"func": {}, "import": {}, "init": {}, "inout": {}, "internal": {}, "let": {}, "open": {},
+	"operator": {}, "private": {}, "precedencegroup": {}, "protocol": {}, "public": {}, "rethrows": {},
+	"static": {}, "struct": {}, "subscript": {}, "typealias": {}, "var": {}, "break": {}, "case": {},
+	"catch": {}, "continue": {}, "default": {}, "defer": {}, "do": {}, "else": {}, "fallthrough": {},
+	"for": {}, "guard": {}, "if": {}, "in": {}, "repeat": {}, "return": {}, "throw": {}, "switch": {},
+	"where": {}, "while": {}, "as": {}, "false": {}, "is": {}, "nil": {}, "self": {}, "super": {},
+	"throws": {}, "true": {}, "try": {},
+}
+
+func identifier(name string) string {
+	if _, ok := swiftKeywords[name]; ok {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func nodeStructName(key dictionary.EntryKey) string {
+	return "D_" + key.PascalCase()
+}
+
+func nodePropertyName(child string) string {
+	return identifier(code.ToCamelCase(child))
+}
+
+func entryMethodName(key dictionary.EntryKey) string {
+	return identifier(code.ToCamelCase(key.LastPart()))
+}
+
+func argumentName(templateKey string) string {
+	return identifier(code.TemplateKeyToCamelCase(templateKey))
+}
+
+func entryFormattersName(key dictionary.EntryKey) string {
+	return "d_" + key.PascalCase() + "_Fmt"
+}
+
+func pluralSelectorFnName(language string) string {
+	return "l_plural_" + strings.ReplaceAll(language, "-", "_")
+}
@@ -0,0 +1,196 @@
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var tomlBareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// TomlDictionaryExporter writes content and metadata in TOML.
+// Each entry is a table, and texts with line breaks are written as multi-line strings.
+//
+// Options:
+//   - nested: write content as tables nested by the parts of entry keys, such as `[screens.title]`,
+//     instead of flat tables such as `["screens.title"]`.
+//
+// When writing a project, comments of the existing files are kept, and content is written in the form
+// of the existing content file unless 'nested' is given.
+type TomlDictionaryExporter struct{}
+
+func (t TomlDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return validateNestedOption(options)
+}
+
+func (t TomlDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	var metadataBuffer bytes.Buffer
+	if err := t.ExportMetadata(&metadataBuffer, metadata, options); err != nil {
+		return err
+	}
+	if err := t.rewriteFile(path.Join(projectRoot, "metadata.toml"), metadataBuffer.String()); err != nil {
+		return errors.Wrap(err, "failed to write metadata")
+	}
+
+	contentPath := path.Join(projectRoot, "content.toml")
+	nested, ok := nestedOption(options)
+	if !ok {
+		nested = t.isNestedFile(contentPath)
+	}
+	if err := t.rewriteContent(contentPath, content, nested); err != nil {
+		return errors.Wrap(err, "failed to write content")
+	}
+	return nil
+}
+
+func (t TomlDictionaryExporter) RewriteContentFile(filePath string, content dictionary.ContentRepresentation) error {
+	return t.rewriteContent(filePath, content, t.isNestedFile(filePath))
+}
+
+func (t TomlDictionaryExporter) rewriteContent(filePath string, content dictionary.ContentRepresentation, nested bool) error {
+	var contentBuffer bytes.Buffer
+	if err := t.exportContent(&contentBuffer, content, nested); err != nil {
+		return err
+	}
+	return t.rewriteFile(filePath, contentBuffer.String())
+}
+
+func (t TomlDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	_ dictionary.Metadata,
+	options OptionMap,
+) error {
+	nested, _ := nestedOption(options)
+	return t.exportContent(file, content, nested)
+}
+
+func (t TomlDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	value, err := metadataValue(metadata)
+	if err != nil {
+		return err
+	}
+	encoder := toml.NewEncoder(file)
+	encoder.Indent = ""
+	if err := encoder.Encode(value); err != nil {
+		return errors.Wrap(err, "failed to encode TOML")
+	}
+	return nil
+}
+
+func (t TomlDictionaryExporter) exportContent(file io.Writer, content dictionary.ContentRepresentation, nested bool) error {
+	var builder strings.Builder
+	if nested {
+		t.writeNestedTables(&builder, content.ToTree())
+	} else {
+		flattened := content.ToFlattened()
+		for _, key := range sortedEntryKeys(flattened) {
+			t.writeTable(&builder, []string{string(key)}, (*flattened)[key])
+		}
+	}
+	_, err := io.WriteString(file, strings.TrimPrefix(builder.String(), "\n"))
+	return err
+}
+
+func (t TomlDictionaryExporter) writeNestedTables(builder *strings.Builder, node *dictionary.ContentNode) {
+	for _, member := range nestedMembers(node) {
+		if member.SelfEntry {
+			t.writeTable(builder, append(member.Key.Parts(), selfEntryName), member.Entry)
+		} else if len(member.Fields) > 0 {
+			t.writeTable(builder, member.Key.Parts(), member.Entry)
+		}
+		if member.Child != nil {
+			t.writeNestedTables(builder, member.Child)
+		}
+	}
+}
+
+func (t TomlDictionaryExporter) writeTable(builder *strings.Builder, keyParts []string, entry dictionary.Entry) {
+	quotedParts := make([]string, len(keyParts))
+	for index, part := range keyParts {
+		quotedParts[index] = tomlKey(part)
+	}
+	fmt.Fprintf(builder, "\n[%s]\n", strings.Join(quotedParts, "."))
+	for _, field := range sortedEntryFields(entry) {
+		fmt.Fprintf(builder, "%s = %s\n", tomlKey(field), tomlString(entry[field]))
+	}
+}
+
+// rewriteFile writes a document to a file, keeping the comments of the existing file.
+func (t TomlDictionaryExporter) rewriteFile(filePath string, document string) error {
+	if previous, err := os.ReadFile(filePath); err == nil {
+		document = applyTomlComments(document, collectTomlComments(string(previous)))
+	}
+	return os.WriteFile(filePath, []byte(document), os.ModePerm)
+}
+
+// isNestedFile returns whether an existing content file is written in the nested form.
+func (t TomlDictionaryExporter) isNestedFile(filePath string) bool {
+	decoded := map[string]interface{}{}
+	if _, err := toml.DecodeFile(filePath, &decoded); err != nil {
+		return false
+	}
+	return isNestedContentValue(decoded)
+}
+
+func tomlKey(key string) string {
+	if tomlBareKeyRegex.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlString returns a TOML basic string, or a multi-line basic string if value has line breaks.
+func tomlString(value string) string {
+	multiLine := strings.Contains(value, "\n")
+	var builder strings.Builder
+	if multiLine {
+		builder.WriteString("\"\"\"\n")
+	} else {
+		builder.WriteString("\"")
+	}
+	runes := []rune(value)
+	for index, char := range runes {
+		switch {
+		case char == '\\':
+			builder.WriteString(`\\`)
+		case char == '"' && multiLine && !tomlClosesQuotes(runes, index):
+			builder.WriteRune(char)
+		case char == '"':
+			builder.WriteString(`\"`)
+		case char == '\n' && multiLine, char == '\t':
+			builder.WriteRune(char)
+		case char == '\r':
+			builder.WriteString(`\r`)
+		case char < 0x20 || char == 0x7f:
+			fmt.Fprintf(&builder, `\u%04X`, char)
+		default:
+			builder.WriteRune(char)
+		}
+	}
+	if multiLine {
+		builder.WriteString(`"""`)
+	} else {
+		builder.WriteString("\"")
+	}
+	return builder.String()
+}
+
+// tomlClosesQuotes returns whether a quote in a multi-line string should be escaped,
+// as it could form closing quotes with the following quotes.
+func tomlClosesQuotes(runes []rune, index int) bool {
+	return index == len(runes)-1 || runes[index+1] == '"'
+}
@@ -0,0 +1,309 @@
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tomlStatement is a table header or a key/value pair of a TOML document.
+type tomlStatement struct {
+	// path is the dotted path of the table or the key. Tables of arrays are keyed by their indices.
+	path      string
+	firstLine int
+	lastLine  int
+	// head are the comment lines right before the statement.
+	head []string
+	// comment is the comment at the end of the statement.
+	comment string
+}
+
+// tomlScanner scans statements of a TOML document, to find the comments of keys.
+// The document is expected to be valid, but the scanner does not fail on invalid documents.
+type tomlScanner struct {
+	text string
+	pos  int
+	line int
+}
+
+// scanTomlStatements returns the statements of a document, with the comments before the first statement
+// separated by a blank line, and the comments after the last statement.
+func scanTomlStatements(text string) (statements []tomlStatement, documentHead []string, documentFoot []string) {
+	scanner := &tomlScanner{text: text}
+	tablePath := ""
+	arrayCounts := map[string]int{}
+	pending := []string{}
+
+	for !scanner.done() {
+		scanner.skipSpaces()
+		switch scanner.peek() {
+		case '\r', '\n':
+			if len(statements) == 0 {
+				documentHead = append(documentHead, pending...)
+				pending = []string{}
+			}
+			scanner.skipLine()
+			continue
+		case '#':
+			pending = append(pending, scanner.readComment())
+			scanner.skipLine()
+			continue
+		}
+
+		statement := tomlStatement{firstLine: scanner.line, head: pending}
+		pending = []string{}
+		if scanner.peek() == '[' {
+			isArray := strings.HasPrefix(scanner.text[scanner.pos:], "[[")
+			scanner.advance()
+			if isArray {
+				scanner.advance()
+			}
+			tablePath = strings.Join(scanner.readKey(), ".")
+			if isArray {
+				index := arrayCounts[tablePath]
+				arrayCounts[tablePath]++
+				tablePath = fmt.Sprintf("%s.%d", tablePath, index)
+			}
+			for !scanner.done() && scanner.peek() == ']' {
+				scanner.advance()
+			}
+			statement.path = tablePath
+		} else {
+			statement.path = joinCommentPath(tablePath, strings.Join(scanner.readKey(), "."))
+			if scanner.peek() == '=' {
+				scanner.advance()
+			}
+			scanner.skipValue()
+		}
+		scanner.skipSpaces()
+		if scanner.peek() == '#' {
+			statement.comment = scanner.readComment()
+		}
+		statement.lastLine = scanner.line
+		statements = append(statements, statement)
+		scanner.skipLine()
+	}
+	return statements, documentHead, pending
+}
+
+func (t *tomlScanner) done() bool {
+	return t.pos >= len(t.text)
+}
+
+func (t *tomlScanner) peek() byte {
+	if t.done() {
+		return 0
+	}
+	return t.text[t.pos]
+}
+
+func (t *tomlScanner) advance() {
+	if t.done() {
+		return
+	}
+	if t.text[t.pos] == '\n' {
+		t.line++
+	}
+	t.pos++
+}
+
+func (t *tomlScanner) skipSpaces() {
+	for t.peek() == ' ' || t.peek() == '\t' {
+		t.advance()
+	}
+}
+
+// skipLine skips the rest of the line including the line break.
+func (t *tomlScanner) skipLine() {
+	for !t.done() && t.peek() != '\n' {
+		t.advance()
+	}
+	t.advance()
+}
+
+// readComment reads a comment until the end of the line, excluding the line break.
+func (t *tomlScanner) readComment() string {
+	start := t.pos
+	for !t.done() && t.peek() != '\n' && t.peek() != '\r' {
+		t.advance()
+	}
+	return t.text[start:t.pos]
+}
+
+// readKey reads a dotted key and returns its parts.
+func (t *tomlScanner) readKey() []string {
+	parts := []string{}
+	for {
+		t.skipSpaces()
+		switch t.peek() {
+		case '"':
+			raw := t.readQuoted('"', true)
+			if unquoted, err := strconv.Unquote(raw); err == nil {
+				parts = append(parts, unquoted)
+			} else {
+				parts = append(parts, strings.Trim(raw, `"`))
+			}
+		case '\'':
+			parts = append(parts, strings.Trim(t.readQuoted('\'', false), "'"))
+		default:
+			start := t.pos
+			for !t.done() && !strings.ContainsRune(" \t\r\n.=]#", rune(t.peek())) {
+				t.advance()
+			}
+			parts = append(parts, t.text[start:t.pos])
+		}
+		t.skipSpaces()
+		if t.peek() != '.' {
+			return parts
+		}
+		t.advance()
+	}
+}
+
+// readQuoted reads a single line string including its quotes.
+func (t *tomlScanner) readQuoted(quote byte, escapes bool) string {
+	start := t.pos
+	t.advance()
+	for !t.done() && t.peek() != quote && t.peek() != '\n' {
+		if escapes && t.peek() == '\\' {
+			t.advance()
+		}
+		t.advance()
+	}
+	t.advance()
+	return t.text[start:t.pos]
+}
+
+// skipMultiLine skips a multi-line string.
+func (t *tomlScanner) skipMultiLine(quotes string, escapes bool) {
+	for i := 0; i < len(quotes); i++ {
+		t.advance()
+	}
+	for !t.done() && !strings.HasPrefix(t.text[t.pos:], quotes) {
+		if escapes && t.peek() == '\\' {
+			t.advance()
+		}
+		t.advance()
+	}
+	for i := 0; i < len(quotes); i++ {
+		t.advance()
+	}
+	// Up to two quotes can be right before the closing quotes.
+	for i := 0; i < 2 && t.peek() == quotes[0]; i++ {
+		t.advance()
+	}
+}
+
+func (t *tomlScanner) skipValue() {
+	t.skipSpaces()
+	rest := t.text[t.pos:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		t.skipMultiLine(`"""`, true)
+	case strings.HasPrefix(rest, `'''`):
+		t.skipMultiLine(`'''`, false)
+	case t.peek() == '"':
+		t.readQuoted('"', true)
+	case t.peek() == '\'':
+		t.readQuoted('\'', false)
+	case t.peek() == '[':
+		t.advance()
+		for !t.done() {
+			switch t.peek() {
+			case ' ', '\t', '\r', '\n', ',':
+				t.advance()
+			case '#':
+				t.readComment()
+			case ']':
+				t.advance()
+				return
+			default:
+				t.skipNestedValue(false)
+			}
+		}
+	case t.peek() == '{':
+		t.advance()
+		for !t.done() {
+			switch t.peek() {
+			case ' ', '\t', ',':
+				t.advance()
+			case '}':
+				t.advance()
+				return
+			default:
+				t.skipNestedValue(true)
+			}
+		}
+	default:
+		for !t.done() && !strings.ContainsRune(" \t\r\n,]}#", rune(t.peek())) {
+			t.advance()
+		}
+	}
+}
+
+// skipNestedValue skips a value in an array, or a key/value pair in an inline table.
+// Invalid characters are skipped, so that the scanner does not get stuck.
+func (t *tomlScanner) skipNestedValue(withKey bool) {
+	start := t.pos
+	if withKey {
+		t.readKey()
+		if t.peek() == '=' {
+			t.advance()
+		}
+	}
+	t.skipValue()
+	if t.pos == start {
+		t.advance()
+	}
+}
+
+// collectTomlComments returns the comments of a document by the paths of their keys.
+func collectTomlComments(text string) documentComments {
+	statements, head, foot := scanTomlStatements(text)
+	comments := newDocumentComments()
+	comments.Head, comments.Foot = strings.Join(head, "\n"), strings.Join(foot, "\n")
+	for _, statement := range statements {
+		if len(statement.head) > 0 || statement.comment != "" {
+			comments.Keys[statement.path] = keyComments{Head: strings.Join(statement.head, "\n"), Line: statement.comment}
+		}
+	}
+	return comments
+}
+
+// applyTomlComments adds the comments of keys with the same paths to a document.
+func applyTomlComments(text string, comments documentComments) string {
+	statements, _, _ := scanTomlStatements(text)
+	heads, lineComments := map[int]string{}, map[int]string{}
+	for _, statement := range statements {
+		keyComment, ok := comments.Keys[statement.path]
+		if !ok {
+			continue
+		}
+		if keyComment.Head != "" {
+			heads[statement.firstLine] = keyComment.Head
+		}
+		if keyComment.Line != "" && statement.comment == "" {
+			lineComments[statement.lastLine] = keyComment.Line
+		}
+	}
+
+	var builder strings.Builder
+	if comments.Head != "" {
+		builder.WriteString(comments.Head + "\n\n")
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for index, line := range lines {
+		if head, ok := heads[index]; ok {
+			builder.WriteString(head + "\n")
+		}
+		builder.WriteString(line)
+		if comment, ok := lineComments[index]; ok {
+			builder.WriteString(" " + comment)
+		}
+		builder.WriteString("\n")
+	}
+	if comments.Foot != "" {
+		builder.WriteString("\n" + comments.Foot + "\n")
+	}
+	return builder.String()
+}
@@ -180,7 +180,7 @@ func (t *typescriptBuilder) writeEntryDataToBuilder(fullKey dictionary.EntryKey,
 	t.dataBuilder.AppendLines(fmt.Sprintf(`"%s": {`, t.shortener.Shorten(string(fullKey))))
 	t.dataBuilder.Indent()
 	for lang, value := range entry {
-		if lang == "context" {
+		if dictionary.IsEntryMetaField(lang) {
 			continue
 		}
 		err := t.options.WriteEntryData(&t.dataBuilder, argType, lang, value, entry)
@@ -2,6 +2,7 @@ package typescript
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/maasasia/donggu/code"
 	"github.com/maasasia/donggu/dictionary"
@@ -12,11 +13,17 @@ type typescriptPluralBuilder struct{}
 func (t typescriptPluralBuilder) Build(metadata dictionary.Metadata) code.IndentedCodeBuilder {
 	builder := code.IndentedCodeBuilder{}
 
+	if len(metadata.CldrPlurals) > 0 {
+		t.buildOperandFunction(&builder)
+	}
+
 	builder.AppendLines("export const PLURALS = {")
 	builder.Indent()
 
 	for _, lang := range metadata.SupportedLanguages {
-		if defs, ok := metadata.Plurals[lang]; ok {
+		if rules, ok := metadata.PluralRules(lang); ok {
+			t.buildCldrLanguage(lang, rules, &builder)
+		} else if defs, ok := metadata.Plurals[lang]; ok {
 			t.buildLanguage(lang, defs, &builder)
 		} else {
 			t.buildLanguage(lang, dictionary.DefaultPluralDefinition(), &builder)
@@ -29,20 +36,116 @@ func (t typescriptPluralBuilder) Build(metadata dictionary.Metadata) code.Indent
 }
 
 func (t typescriptPluralBuilder) buildLanguage(lang string, defs []dictionary.PluralDefinition, builder *code.IndentedCodeBuilder) {
-	builder.AppendLines(fmt.Sprintf(`"%s": (v: number) => {`, lang))
+	builder.AppendLines(fmt.Sprintf(`"%s": (v: number): string => {`, lang))
 	builder.Indent()
 
 	for index, def := range defs {
+		category := def.CategoryName(index)
 		if def.HasOperand {
-			builder.AppendLines(fmt.Sprintf("if (v%s%d === %d) return %d;", def.Op, def.Operand, def.Equals, index))
+			builder.AppendLines(fmt.Sprintf(`if (v%s%d === %d) return "%s";`, def.Op, def.Operand, def.Equals, category))
 		} else if def.Op == "==" {
-			builder.AppendLines(fmt.Sprintf("if (v === %d) return %d;", def.Equals, index))
+			builder.AppendLines(fmt.Sprintf(`if (v === %d) return "%s";`, def.Equals, category))
 		} else {
-			builder.AppendLines(fmt.Sprintf("if (v %s %d) return %d;", def.Op, def.Equals, index))
+			builder.AppendLines(fmt.Sprintf(`if (v %s %d) return "%s";`, def.Op, def.Equals, category))
 		}
 	}
-	builder.AppendLines(fmt.Sprintf("return %d;", len(defs)))
+	builder.AppendLines(fmt.Sprintf(`return "%s";`, dictionary.OtherPluralCategory))
 
 	builder.Unindent()
 	builder.AppendLines("},")
 }
+
+func (t typescriptPluralBuilder) buildOperandFunction(builder *code.IndentedCodeBuilder) {
+	builder.AppendLines("const pluralOperands = (v: number) => {")
+	builder.IndentedLines(
+		"const n = Math.abs(v);",
+		`const [intPart, fracPart = ""] = n.toString().split(".");`,
+		`const trimmedFracPart = fracPart.replace(/0+$/, "");`,
+		"return {",
+		"  n,",
+		"  i: parseInt(intPart, 10),",
+		"  v: fracPart.length,",
+		"  w: trimmedFracPart.length,",
+		`  f: fracPart === "" ? 0 : parseInt(fracPart, 10),`,
+		`  t: trimmedFracPart === "" ? 0 : parseInt(trimmedFracPart, 10),`,
+		"  e: 0,",
+		"  c: 0,",
+		"};",
+	)
+	builder.AppendLines("};", "")
+}
+
+func (t typescriptPluralBuilder) buildCldrLanguage(lang string, rules []dictionary.PluralRule, builder *code.IndentedCodeBuilder) {
+	builder.AppendLines(fmt.Sprintf(`"%s": (v: number): string => {`, lang))
+	builder.Indent()
+
+	if len(rules) > 0 {
+		builder.AppendLines("const o = pluralOperands(v);")
+	}
+	for _, rule := range rules {
+		builder.AppendLines(fmt.Sprintf(`if (%s) return "%s";`, t.conditionCode(rule.Condition), rule.Category))
+	}
+	builder.AppendLines(fmt.Sprintf(`return "%s";`, dictionary.OtherPluralCategory))
+
+	builder.Unindent()
+	builder.AppendLines("},")
+}
+
+func (t typescriptPluralBuilder) conditionCode(condition dictionary.PluralCondition) string {
+	if len(condition) == 0 {
+		return "false"
+	}
+	groups := make([]string, 0, len(condition))
+	for _, group := range condition {
+		if len(group) == 0 {
+			return "true"
+		}
+		relations := make([]string, 0, len(group))
+		for _, relation := range group {
+			relations = append(relations, t.relationCode(relation))
+		}
+		if len(condition) > 1 && len(relations) > 1 {
+			groups = append(groups, "("+strings.Join(relations, " && ")+")")
+		} else {
+			groups = append(groups, strings.Join(relations, " && "))
+		}
+	}
+	return strings.Join(groups, " || ")
+}
+
+func (t typescriptPluralBuilder) relationCode(relation dictionary.PluralRelation) string {
+	operand := fmt.Sprintf("o.%c", relation.Operand)
+	if relation.Modulo != 0 {
+		operand = fmt.Sprintf("%s %% %d", operand, relation.Modulo)
+	}
+
+	if len(relation.Ranges) == 1 && relation.Ranges[0].From == relation.Ranges[0].To {
+		if relation.Negated {
+			return fmt.Sprintf("%s !== %d", operand, relation.Ranges[0].From)
+		}
+		return fmt.Sprintf("%s === %d", operand, relation.Ranges[0].From)
+	}
+
+	hasRange := false
+	alternatives := make([]string, 0, len(relation.Ranges))
+	for _, rng := range relation.Ranges {
+		if rng.From == rng.To {
+			alternatives = append(alternatives, fmt.Sprintf("%s === %d", operand, rng.From))
+		} else {
+			hasRange = true
+			alternatives = append(alternatives, fmt.Sprintf("%s >= %d && %s <= %d", operand, rng.From, operand, rng.To))
+		}
+	}
+	code := strings.Join(alternatives, " || ")
+	if hasRange && relation.Operand == 'n' {
+		if len(alternatives) > 1 {
+			code = "(" + code + ")"
+		}
+		code = fmt.Sprintf("Number.isInteger(%s) && %s", operand, code)
+	}
+	code = "(" + code + ")"
+	if relation.Negated {
+		code = "!" + code
+	}
+	return code
+}
@@ -2,12 +2,11 @@ package typescript
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/maasasia/donggu/code"
 	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
 )
 
 type reactArgumentFormatter struct {
@@ -42,12 +41,12 @@ func (r reactArgumentFormatter) formatBool(key string, format dictionary.Templat
 }
 
 func (r reactArgumentFormatter) formatPlural(language, key string, format dictionary.TemplateKeyFormat) (string, error) {
-	options := format.Option.([]string)
-	optArray := strings.Join(options, `","`)
-	if !checkPluralOptionLength(format, language, r.metadata) {
-		return "", errors.New("plural option length does not match")
+	choices, err := pluralChoices(format, language, r.metadata)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid plural choices")
 	}
-	return fmt.Sprintf(`Formatter.plural(param.%s, "%s", ["%s"])`, key, language, optArray), nil
+	choiceObject, _ := json.Marshal(choices)
+	return fmt.Sprintf(`Formatter.plural(param.%s, "%s", %s)`, key, language, choiceObject), nil
 }
 
 func (r reactArgumentFormatter) numericOptions(key string, format dictionary.TemplateKeyFormat) string {
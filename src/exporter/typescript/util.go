@@ -13,11 +13,16 @@ func escapeTemplateStringLiteral(str string) string {
 	return str
 }
 
-func checkPluralOptionLength(format dictionary.TemplateKeyFormat, language string, metadata *dictionary.Metadata) bool {
-	optionLength := len(format.Option.([]string))
-	if defs, ok := metadata.Plurals[language]; ok {
-		return optionLength == len(defs)+1
-	} else {
-		return optionLength == 2
+// pluralChoices returns the choices of a plural template keyed by category names.
+func pluralChoices(format dictionary.TemplateKeyFormat, language string, metadata *dictionary.Metadata) (map[string]string, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(metadata.PluralCategories(language))
+	if err != nil {
+		return nil, err
 	}
+	result := make(map[string]string, len(choices))
+	for category, choice := range choices {
+		result[string(category)] = choice
+	}
+	return result, nil
 }
@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// VueI18nDictionaryExporter writes messages for vue-i18n (https://vue-i18n.intlify.dev).
+//
+// Templates are converted to named interpolations (`{NAME}`), and characters with special meaning
+// in the message syntax are written as literal interpolations (`{'@'}`).
+// Texts with plural templates are written as a form for each plural category of the language,
+// separated by `|`. Languages with other categories than `one` and `other` need pluralRules
+// to select the forms in the same order.
+//
+// ExportContent writes the messages of all languages keyed by language, and Export writes a file for each language.
+//
+// Options:
+//   - nested: write messages nested by the parts of entry keys. Defaults to true.
+//     Entries under an entry with a text, such as `greet.sub` under `greet`, are written with their flat keys at the top level.
+type VueI18nDictionaryExporter struct{}
+
+func (v VueI18nDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessageFiles(projectRoot, content, metadata, libraryNestedOption(options, true), v.formatMessages)
+}
+
+func (v VueI18nDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return exportLibraryMessages(file, content, metadata, libraryNestedOption(options, true), v.formatMessages)
+}
+
+func (v VueI18nDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (v VueI18nDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return validateNestedOption(options)
+}
+
+func (v VueI18nDictionaryExporter) formatMessages(entry dictionary.Entry, lang string, metadata dictionary.Metadata) ([]libraryMessage, error) {
+	pluralKey, err := libraryPluralKey(entry[lang])
+	if err != nil {
+		return nil, err
+	}
+	forms := []string{entry[lang]}
+	if pluralKey != "" {
+		if forms, err = poPluralForms(entry[lang], lang, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	texts := make([]string, len(forms))
+	for index, form := range forms {
+		if texts[index], err = v.formatText(form); err != nil {
+			return nil, err
+		}
+	}
+	return []libraryMessage{{Text: strings.Join(texts, " | ")}}, nil
+}
+
+func (v VueI18nDictionaryExporter) formatText(text string) (string, error) {
+	entry := dictionary.Entry{"": text}
+	return entry.ReplacedTemplateValueEscaped("", v.escapeLiteral, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		switch format.Kind {
+		case dictionary.IntTemplateKeyType, dictionary.FloatTemplateKeyType:
+			if !format.Option.(dictionary.NumericTemplateFormatOption).IsZero() {
+				return "", errors.Errorf("cannot convert template '%s': number formats have no vue-i18n equivalent", key)
+			}
+			return fmt.Sprintf("{%s}", key), nil
+		case dictionary.BoolTemplateKeyType:
+			return "", errors.Errorf("cannot convert template '%s': bool templates have no vue-i18n equivalent", key)
+		default:
+			return fmt.Sprintf("{%s}", key), nil
+		}
+	})
+}
+
+// escapeLiteral writes characters with special meaning in vue-i18n messages as literal interpolations.
+func (v VueI18nDictionaryExporter) escapeLiteral(text string) string {
+	builder := strings.Builder{}
+	for _, r := range text {
+		switch r {
+		case '{', '}', '@', '|':
+			builder.WriteString("{'" + string(r) + "'}")
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,340 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	xliffVersion12 = "1.2"
+	xliffVersion20 = "2.0"
+)
+
+var xliffEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// XliffDictionaryExporter is a DictionaryExporter for XLIFF 1.2 and 2.0 documents.
+// The version is set by the option 'version', and defaults to 1.2.
+//
+// The first required language is used as the source language, and every other supported language
+// is a target language. Entry keys are used as unit ids, and contexts are written as notes.
+// Templates are written as placeholders so that they are protected by translation tools,
+// except plural and bool templates whose choices need to be translated.
+//
+// Since XLIFF 2.0 documents have a single target language, ExportContent only supports 2.0
+// if there is one target language. Export writes a document for each target language.
+type XliffDictionaryExporter struct{}
+
+// xliffPart is a part of a text. Either text or template is set.
+type xliffPart struct {
+	text     string
+	template string
+}
+
+// xliffPlaceholder is a protected template of a unit.
+type xliffPlaceholder struct {
+	id       string
+	dataId   string
+	template string
+}
+
+// xliffUnitWriter assigns placeholder ids shared by the source and target of a unit.
+type xliffUnitWriter struct {
+	placeholders []xliffPlaceholder
+	dataIds      map[string]string
+}
+
+func (x XliffDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	for _, lang := range xliffTargetLanguages(metadata) {
+		file, err := os.OpenFile(path.Join(projectRoot, lang+".xlf"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open file for language '%s'", lang)
+		}
+		err = x.writeDocument(file, []string{lang}, content, metadata, options)
+		file.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to write language '%s'", lang)
+		}
+	}
+	return nil
+}
+
+func (x XliffDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	return x.writeDocument(file, xliffTargetLanguages(metadata), content, metadata, options)
+}
+
+func (x XliffDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	return errors.New("unsupported")
+}
+
+func (x XliffDictionaryExporter) ValidateOptions(options OptionMap) error {
+	convOpts := map[string]interface{}(options)
+	if _, ok := convOpts["version"]; !ok {
+		return nil
+	}
+	version, err := util.SafeAccessMap[string](&convOpts, "version")
+	if err != nil {
+		return err
+	}
+	if version != xliffVersion12 && version != xliffVersion20 {
+		return errors.Errorf("unsupported XLIFF version '%s' (key 'version')", version)
+	}
+	return nil
+}
+
+func (x XliffDictionaryExporter) writeDocument(
+	file io.Writer,
+	targetLanguages []string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	if len(metadata.RequiredLanguages) == 0 {
+		return errors.New("a required language is needed as the source language")
+	}
+	sourceLanguage := metadata.RequiredLanguages[0]
+
+	flattened := content.ToFlattened()
+	keys := make([]string, 0, len(*flattened))
+	for key := range *flattened {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	builder := strings.Builder{}
+	builder.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+
+	version := xliffVersion12
+	if optionVersion, ok := options["version"].(string); ok {
+		version = optionVersion
+	}
+	if version == xliffVersion20 {
+		if len(targetLanguages) != 1 {
+			return errors.New("XLIFF 2.0 documents can only have a single target language. Export to a directory instead")
+		}
+		builder.WriteString(fmt.Sprintf(
+			"<xliff version=\"2.0\" xmlns=\"urn:oasis:names:tc:xliff:document:2.0\" srcLang=\"%s\" trgLang=\"%s\">\n",
+			xliffEscaper.Replace(sourceLanguage), xliffEscaper.Replace(targetLanguages[0]),
+		))
+		builder.WriteString(fmt.Sprintf("  <file id=\"%s\">\n", xliffEscaper.Replace(targetLanguages[0])))
+		for _, key := range keys {
+			if err := writeXliff20Unit(&builder, key, (*flattened)[dictionary.EntryKey(key)], sourceLanguage, targetLanguages[0]); err != nil {
+				return errors.Wrapf(err, "invalid entry '%s'", key)
+			}
+		}
+		builder.WriteString("  </file>\n")
+	} else {
+		builder.WriteString("<xliff version=\"1.2\" xmlns=\"urn:oasis:names:tc:xliff:document:1.2\">\n")
+		for _, lang := range targetLanguages {
+			builder.WriteString(fmt.Sprintf(
+				"  <file original=\"content.json\" datatype=\"plaintext\" source-language=\"%s\" target-language=\"%s\">\n",
+				xliffEscaper.Replace(sourceLanguage), xliffEscaper.Replace(lang),
+			))
+			builder.WriteString("    <body>\n")
+			for _, key := range keys {
+				if err := writeXliff12Unit(&builder, key, (*flattened)[dictionary.EntryKey(key)], sourceLanguage, lang); err != nil {
+					return errors.Wrapf(err, "invalid entry '%s'", key)
+				}
+			}
+			builder.WriteString("    </body>\n")
+			builder.WriteString("  </file>\n")
+		}
+	}
+	builder.WriteString("</xliff>\n")
+
+	if _, err := io.WriteString(file, builder.String()); err != nil {
+		return errors.Wrap(err, "error while writing file")
+	}
+	return nil
+}
+
+func writeXliff12Unit(builder *strings.Builder, key string, entry dictionary.Entry, sourceLanguage, targetLanguage string) error {
+	unitWriter := newXliffUnitWriter()
+	source, err := unitWriter.content12(entry[sourceLanguage])
+	if err != nil {
+		return err
+	}
+
+	builder.WriteString(fmt.Sprintf("      <trans-unit id=\"%s\" xml:space=\"preserve\">\n", xliffEscaper.Replace(key)))
+	builder.WriteString(fmt.Sprintf("        <source>%s</source>\n", source))
+	if value, ok := entry[targetLanguage]; ok {
+		target, err := unitWriter.content12(value)
+		if err != nil {
+			return err
+		}
+		builder.WriteString(fmt.Sprintf("        <target>%s</target>\n", target))
+	}
+	if context := entry["context"]; context != "" {
+		builder.WriteString(fmt.Sprintf("        <note>%s</note>\n", xliffEscaper.Replace(context)))
+	}
+	builder.WriteString("      </trans-unit>\n")
+	return nil
+}
+
+func writeXliff20Unit(builder *strings.Builder, key string, entry dictionary.Entry, sourceLanguage, targetLanguage string) error {
+	unitWriter := newXliffUnitWriter()
+	source, err := unitWriter.content20(entry[sourceLanguage])
+	if err != nil {
+		return err
+	}
+	target, hasTarget := "", false
+	if value, ok := entry[targetLanguage]; ok {
+		if target, err = unitWriter.content20(value); err != nil {
+			return err
+		}
+		hasTarget = true
+	}
+
+	builder.WriteString(fmt.Sprintf("    <unit id=\"%s\">\n", xliffEscaper.Replace(key)))
+	if context := entry["context"]; context != "" {
+		builder.WriteString(fmt.Sprintf("      <notes>\n        <note>%s</note>\n      </notes>\n", xliffEscaper.Replace(context)))
+	}
+	if len(unitWriter.dataIds) > 0 {
+		builder.WriteString("      <originalData>\n")
+		written := map[string]struct{}{}
+		for _, placeholder := range unitWriter.placeholders {
+			if _, ok := written[placeholder.dataId]; ok {
+				continue
+			}
+			written[placeholder.dataId] = struct{}{}
+			builder.WriteString(fmt.Sprintf(
+				"        <data id=\"%s\">%s</data>\n", placeholder.dataId, xliffEscaper.Replace(placeholder.template),
+			))
+		}
+		builder.WriteString("      </originalData>\n")
+	}
+	builder.WriteString("      <segment>\n")
+	builder.WriteString(fmt.Sprintf("        <source xml:space=\"preserve\">%s</source>\n", source))
+	if hasTarget {
+		builder.WriteString(fmt.Sprintf("        <target xml:space=\"preserve\">%s</target>\n", target))
+	}
+	builder.WriteString("      </segment>\n")
+	builder.WriteString("    </unit>\n")
+	return nil
+}
+
+func newXliffUnitWriter() *xliffUnitWriter {
+	return &xliffUnitWriter{dataIds: map[string]string{}}
+}
+
+func (x *xliffUnitWriter) content12(text string) (string, error) {
+	return x.content(text, func(placeholder xliffPlaceholder) string {
+		return fmt.Sprintf("<ph id=\"%s\">%s</ph>", placeholder.id, xliffEscaper.Replace(placeholder.template))
+	})
+}
+
+func (x *xliffUnitWriter) content20(text string) (string, error) {
+	return x.content(text, func(placeholder xliffPlaceholder) string {
+		return fmt.Sprintf(
+			"<ph id=\"%s\" dataRef=\"%s\" disp=\"%s\"/>",
+			placeholder.id, placeholder.dataId, xliffEscaper.Replace(placeholder.template),
+		)
+	})
+}
+
+// content converts a text to XLIFF inline content, using phFn to write placeholders.
+// Placeholders for the same template reuse the ids used in previous calls,
+// so that the placeholders of a source and its target match.
+func (x *xliffUnitWriter) content(text string, phFn func(xliffPlaceholder) string) (string, error) {
+	parts, err := xliffParts(text)
+	if err != nil {
+		return "", err
+	}
+
+	used := map[string]struct{}{}
+	builder := strings.Builder{}
+	for _, part := range parts {
+		if part.template == "" {
+			builder.WriteString(xliffEscaper.Replace(part.text))
+			continue
+		}
+		placeholder, found := xliffPlaceholder{}, false
+		for _, existing := range x.placeholders {
+			if _, isUsed := used[existing.id]; !isUsed && existing.template == part.template {
+				placeholder, found = existing, true
+				break
+			}
+		}
+		if !found {
+			dataId, ok := x.dataIds[part.template]
+			if !ok {
+				dataId = fmt.Sprintf("d%d", len(x.dataIds)+1)
+				x.dataIds[part.template] = dataId
+			}
+			placeholder = xliffPlaceholder{
+				id:       fmt.Sprint(len(x.placeholders) + 1),
+				dataId:   dataId,
+				template: part.template,
+			}
+			x.placeholders = append(x.placeholders, placeholder)
+		}
+		used[placeholder.id] = struct{}{}
+		builder.WriteString(phFn(placeholder))
+	}
+	return builder.String(), nil
+}
+
+// xliffParts splits a text to literal texts and templates to be protected.
+func xliffParts(text string) ([]xliffPart, error) {
+	parts := []xliffPart{}
+	lastIndex := 0
+	for _, match := range templateOptionRegex.FindAllStringSubmatchIndex(text, -1) {
+		template := text[match[0]:match[1]]
+		kind, option := "", ""
+		if match[4] != -1 {
+			kind = text[match[4]:match[5]]
+		}
+		if match[6] != -1 {
+			option = text[match[6]:match[7]]
+		}
+		format, err := dictionary.ParseTemplateKeyFormat(kind, option)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid template '%s'", template)
+		}
+		if !xliffProtected(format) {
+			continue
+		}
+		if match[0] > lastIndex {
+			parts = append(parts, xliffPart{text: text[lastIndex:match[0]]})
+		}
+		parts = append(parts, xliffPart{template: template})
+		lastIndex = match[1]
+	}
+	if lastIndex < len(text) {
+		parts = append(parts, xliffPart{text: text[lastIndex:]})
+	}
+	return parts, nil
+}
+
+// xliffProtected reports whether a template has no translatable text.
+func xliffProtected(format dictionary.TemplateKeyFormat) bool {
+	return !format.Translatable()
+}
+
+func xliffTargetLanguages(metadata dictionary.Metadata) []string {
+	languages := []string{}
+	for _, lang := range metadata.SupportedLanguages {
+		if len(metadata.RequiredLanguages) > 0 && lang == metadata.RequiredLanguages[0] {
+			continue
+		}
+		languages = append(languages, lang)
+	}
+	return languages
+}
@@ -0,0 +1,259 @@
+package exporter
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// YamlDictionaryExporter writes content and metadata in YAML.
+// Texts with line breaks are written as literal block scalars.
+//
+// Options:
+//   - nested: write content nested by the parts of entry keys, instead of flat.
+//
+// When writing a project, comments of the existing files are kept, and content is written in the form
+// of the existing content file unless 'nested' is given.
+// Comments are kept with the keys they are attached to, so a comment right below the last key of a mapping
+// moves with the key when keys are sorted. A content file mixing flat and nested keys is written nested.
+type YamlDictionaryExporter struct{}
+
+func (y YamlDictionaryExporter) ValidateOptions(options OptionMap) error {
+	return validateNestedOption(options)
+}
+
+func (y YamlDictionaryExporter) Export(
+	projectRoot string,
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	options OptionMap,
+) error {
+	metadataPath := firstExistingPath(path.Join(projectRoot, "metadata.yaml"), path.Join(projectRoot, "metadata.yml"))
+	metadataNode, err := y.buildMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	if err := y.rewriteFile(metadataPath, metadataNode); err != nil {
+		return errors.Wrap(err, "failed to write metadata")
+	}
+
+	contentPath := firstExistingPath(path.Join(projectRoot, "content.yaml"), path.Join(projectRoot, "content.yml"))
+	nested, ok := nestedOption(options)
+	if !ok {
+		nested = y.isNestedFile(contentPath)
+	}
+	if err := y.rewriteContent(contentPath, content, nested); err != nil {
+		return errors.Wrap(err, "failed to write content")
+	}
+	return nil
+}
+
+func (y YamlDictionaryExporter) RewriteContentFile(filePath string, content dictionary.ContentRepresentation) error {
+	return y.rewriteContent(filePath, content, y.isNestedFile(filePath))
+}
+
+func (y YamlDictionaryExporter) rewriteContent(filePath string, content dictionary.ContentRepresentation, nested bool) error {
+	contentNode, err := y.buildContent(content, nested)
+	if err != nil {
+		return err
+	}
+	return y.rewriteFile(filePath, contentNode)
+}
+
+func (y YamlDictionaryExporter) ExportContent(
+	file io.Writer,
+	content dictionary.ContentRepresentation,
+	_ dictionary.Metadata,
+	options OptionMap,
+) error {
+	nested, _ := nestedOption(options)
+	node, err := y.buildContent(content, nested)
+	if err != nil {
+		return err
+	}
+	return y.encode(file, node)
+}
+
+func (y YamlDictionaryExporter) ExportMetadata(file io.Writer, metadata dictionary.Metadata, _ OptionMap) error {
+	node, err := y.buildMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	return y.encode(file, node)
+}
+
+// rewriteFile writes a document to a file, keeping the comments of the existing file.
+func (y YamlDictionaryExporter) rewriteFile(filePath string, document *yaml.Node) error {
+	if previous, err := os.ReadFile(filePath); err == nil {
+		var previousDocument yaml.Node
+		if err := yaml.Unmarshal(previous, &previousDocument); err == nil {
+			applyYamlComments(document, collectYamlComments(&previousDocument))
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := y.encode(&buffer, document); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, buffer.Bytes(), os.ModePerm)
+}
+
+// isNestedFile returns whether an existing content file is written in the nested form.
+func (y YamlDictionaryExporter) isNestedFile(filePath string) bool {
+	previous, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	decoded := map[string]interface{}{}
+	if err := yaml.Unmarshal(previous, &decoded); err != nil {
+		return false
+	}
+	return isNestedContentValue(decoded)
+}
+
+func (y YamlDictionaryExporter) encode(w io.Writer, document *yaml.Node) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return errors.Wrap(err, "failed to encode YAML")
+	}
+	return encoder.Close()
+}
+
+func (y YamlDictionaryExporter) buildMetadata(metadata dictionary.Metadata) (*yaml.Node, error) {
+	value, err := metadataValue(metadata)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := root.Encode(value); err != nil {
+		return nil, errors.Wrap(err, "failed to encode metadata")
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}}, nil
+}
+
+func (y YamlDictionaryExporter) buildContent(content dictionary.ContentRepresentation, nested bool) (*yaml.Node, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if nested {
+		y.addNestedMembers(root, content.ToTree())
+	} else {
+		flattened := content.ToFlattened()
+		for _, key := range sortedEntryKeys(flattened) {
+			entryNode := &yaml.Node{Kind: yaml.MappingNode}
+			y.addFields(entryNode, (*flattened)[key])
+			root.Content = append(root.Content, yamlStringNode(string(key)), entryNode)
+		}
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+}
+
+func (y YamlDictionaryExporter) addNestedMembers(mapping *yaml.Node, node *dictionary.ContentNode) {
+	for _, member := range nestedMembers(node) {
+		memberNode := &yaml.Node{Kind: yaml.MappingNode}
+		if member.SelfEntry {
+			selfNode := &yaml.Node{Kind: yaml.MappingNode}
+			y.addFields(selfNode, member.Entry)
+			memberNode.Content = append(memberNode.Content, yamlStringNode(selfEntryName), selfNode)
+		} else {
+			y.addFields(memberNode, member.Entry)
+		}
+		if member.Child != nil {
+			y.addNestedMembers(memberNode, member.Child)
+		}
+		mapping.Content = append(mapping.Content, yamlStringNode(member.Name), memberNode)
+	}
+}
+
+func (y YamlDictionaryExporter) addFields(mapping *yaml.Node, entry dictionary.Entry) {
+	for _, field := range sortedEntryFields(entry) {
+		valueNode := yamlStringNode(entry[field])
+		if strings.Contains(entry[field], "\n") {
+			valueNode.Style = yaml.LiteralStyle
+		}
+		mapping.Content = append(mapping.Content, yamlStringNode(field), valueNode)
+	}
+}
+
+func yamlStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// collectYamlComments returns the comments of a document by the paths of their keys.
+func collectYamlComments(document *yaml.Node) documentComments {
+	comments := newDocumentComments()
+	comments.Head, comments.Foot = document.HeadComment, document.FootComment
+	if len(document.Content) > 0 {
+		walkYamlKeys(document.Content[0], "", func(keyPath string, key, value *yaml.Node) {
+			line := key.LineComment
+			if line == "" {
+				line = value.LineComment
+			}
+			if key.HeadComment != "" || line != "" || key.FootComment != "" {
+				comments.Keys[keyPath] = keyComments{Head: key.HeadComment, Line: line, Foot: key.FootComment}
+			}
+		})
+	}
+	return comments
+}
+
+// applyYamlComments sets the comments of a document to the comments of keys with the same paths.
+func applyYamlComments(document *yaml.Node, comments documentComments) {
+	document.HeadComment, document.FootComment = comments.Head, comments.Foot
+	if len(document.Content) == 0 {
+		return
+	}
+	walkYamlKeys(document.Content[0], "", func(keyPath string, key, value *yaml.Node) {
+		keyComment, ok := comments.Keys[keyPath]
+		if !ok {
+			return
+		}
+		key.HeadComment, key.FootComment = keyComment.Head, keyComment.Foot
+		if value.Kind == yaml.ScalarNode {
+			value.LineComment = keyComment.Line
+		} else {
+			key.LineComment = keyComment.Line
+		}
+	})
+}
+
+// walkYamlKeys calls fn for every key of mappings under node, with the dotted path of the key.
+// Items of sequences are keyed by their indices.
+func walkYamlKeys(node *yaml.Node, parentPath string, fn func(keyPath string, key, value *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			key, value := node.Content[index], node.Content[index+1]
+			keyPath := joinCommentPath(parentPath, key.Value)
+			fn(keyPath, key, value)
+			walkYamlKeys(value, keyPath, fn)
+		}
+	case yaml.SequenceNode:
+		for index, item := range node.Content {
+			walkYamlKeys(item, joinCommentPath(parentPath, strconv.Itoa(index)), fn)
+		}
+	}
+}
+
+func joinCommentPath(parentPath, name string) string {
+	if parentPath == "" {
+		return name
+	}
+	return parentPath + "." + name
+}
+
+// firstExistingPath returns the first path which exists, or the first path if none exists.
+func firstExistingPath(paths ...string) string {
+	for _, filePath := range paths {
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath
+		}
+	}
+	return paths[0]
+}
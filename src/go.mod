@@ -8,6 +8,7 @@ require (
 )
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/dave/jennifer v1.5.0
 	github.com/fatih/color v1.13.0
 	github.com/manifoldco/promptui v0.9.0
@@ -28,5 +29,5 @@ require (
 require (
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	golang.org/x/text v0.3.7
-	gopkg.in/yaml.v3 v3.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,164 @@
+package icu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// templateSentinel marks the position of a converted template while literal text is escaped.
+const templateSentinel = "\x00"
+
+var cldrCategorySet = map[dictionary.PluralCategory]struct{}{
+	dictionary.ZeroPluralCategory:  {},
+	dictionary.OnePluralCategory:   {},
+	dictionary.TwoPluralCategory:   {},
+	dictionary.FewPluralCategory:   {},
+	dictionary.ManyPluralCategory:  {},
+	dictionary.OtherPluralCategory: {},
+}
+
+// FormatMessage converts the template string of an entry in the given language
+// to an ICU MessageFormat message.
+// Returns an error if the string contains a template without an ICU equivalent.
+func FormatMessage(entry dictionary.Entry, lang string, metadata dictionary.Metadata) (string, error) {
+	arguments := []string{}
+	replaced, err := entry.ReplacedTemplateValue(lang, func(key string, format dictionary.TemplateKeyFormat) (string, error) {
+		argument, formatErr := formatArgument(key, format, lang, metadata)
+		if formatErr != nil {
+			return "", errors.Wrapf(formatErr, "cannot convert template '%s'", key)
+		}
+		arguments = append(arguments, argument)
+		return templateSentinel, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	literals := strings.Split(replaced, templateSentinel)
+	builder := strings.Builder{}
+	for index, literal := range literals {
+		builder.WriteString(escapeLiteral(literal, false))
+		if index < len(arguments) {
+			builder.WriteString(arguments[index])
+		}
+	}
+	return builder.String(), nil
+}
+
+func formatArgument(key string, format dictionary.TemplateKeyFormat, lang string, metadata dictionary.Metadata) (string, error) {
+	switch format.Kind {
+	case dictionary.IntTemplateKeyType, dictionary.FloatTemplateKeyType:
+		skeleton, err := numberSkeleton(format)
+		if err != nil {
+			return "", err
+		}
+		if skeleton == "" {
+			return fmt.Sprintf("{%s, number}", key), nil
+		}
+		return fmt.Sprintf("{%s, number, ::%s}", key, skeleton), nil
+	case dictionary.BoolTemplateKeyType:
+		option := format.Option.(dictionary.BoolTemplateFormatOption)
+		if option.UseLocaleValues {
+			return "", errors.New("bool templates without values have no ICU equivalent")
+		}
+		return fmt.Sprintf(
+			"{%s, select, true {%s} other {%s}}",
+			key, escapeLiteral(option.TrueValue, false), escapeLiteral(option.FalseValue, false),
+		), nil
+	case dictionary.PluralTemplateKeyType:
+		return formatPlural(key, format, lang, metadata)
+	default:
+		return fmt.Sprintf("{%s}", key), nil
+	}
+}
+
+func formatPlural(key string, format dictionary.TemplateKeyFormat, lang string, metadata dictionary.Metadata) (string, error) {
+	option := format.Option.(dictionary.PluralTemplateFormatOption)
+	choices, err := option.ChoicesFor(metadata.PluralCategories(lang))
+	if err != nil {
+		return "", err
+	}
+
+	categories := make([]dictionary.PluralCategory, 0, len(choices))
+	for category := range choices {
+		if _, ok := cldrCategorySet[category]; !ok {
+			return "", errors.Errorf("plural category '%s' has no ICU equivalent", category)
+		}
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categoryIndex(categories[i]) < categoryIndex(categories[j])
+	})
+
+	branches := make([]string, 0, len(categories))
+	for _, category := range categories {
+		branches = append(branches, fmt.Sprintf("%s {%s}", category, escapeLiteral(choices[category], true)))
+	}
+	return fmt.Sprintf("{%s, plural, %s}", key, strings.Join(branches, " ")), nil
+}
+
+// numberSkeleton builds an ICU number skeleton (without the leading `::`) from a numeric template.
+func numberSkeleton(format dictionary.TemplateKeyFormat) (string, error) {
+	option := format.Option.(dictionary.NumericTemplateFormatOption)
+	tokens := []string{}
+
+	isInteger := format.Kind == dictionary.IntTemplateKeyType || (option.PrecisionSet && option.Precision == 0)
+	if isInteger {
+		tokens = append(tokens, "precision-integer")
+	} else if option.PrecisionSet {
+		tokens = append(tokens, "."+strings.Repeat("0", option.Precision))
+	}
+	if !option.CommaSeparator {
+		tokens = append(tokens, "group-off")
+	}
+	if option.AlwaysAddSign {
+		tokens = append(tokens, "sign-always")
+	}
+	if option.WidthSet {
+		// Only zero padding of the integer part can be expressed in ICU.
+		if option.PadCharacter != "0" || option.AlwaysAddSign || option.CommaSeparator {
+			return "", errors.New("width without zero padding has no ICU equivalent")
+		}
+		integerDigits := option.Width
+		if !isInteger {
+			if !option.PrecisionSet {
+				return "", errors.New("width of floats without precision has no ICU equivalent")
+			}
+			integerDigits -= option.Precision + 1
+		}
+		if integerDigits > 0 {
+			tokens = append(tokens, "integer-width/*"+strings.Repeat("0", integerDigits))
+		}
+	}
+	return strings.Join(tokens, " "), nil
+}
+
+// escapeLiteral quotes characters with special meaning in ICU messages.
+// `#` is only special inside plural branches.
+func escapeLiteral(text string, inPlural bool) string {
+	builder := strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r == '\'':
+			builder.WriteString("''")
+		case r == '{' || r == '}' || (inPlural && r == '#'):
+			builder.WriteString("'" + string(r) + "'")
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func categoryIndex(category dictionary.PluralCategory) int {
+	for index, ordered := range dictionary.PluralCategoryOrder {
+		if ordered == category {
+			return index
+		}
+	}
+	return len(dictionary.PluralCategoryOrder)
+}
@@ -0,0 +1,477 @@
+package icu
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var templateKeyRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
+var fractionSkeletonRegex = regexp.MustCompile(`^\.(0+)$`)
+var integerWidthSkeletonRegex = regexp.MustCompile(`^integer-width/[*+](0+)$`)
+
+// numberMarker marks the position of `#` inside a plural branch.
+const numberMarker = '\x00'
+
+type messageParser struct {
+	input []rune
+	pos   int
+}
+
+// ParseMessage converts an ICU MessageFormat message to a donggu template string.
+// Returns an error if the message contains a construct without a donggu equivalent,
+// such as nested arguments inside a select branch.
+func ParseMessage(message string) (string, error) {
+	parser := messageParser{input: []rune(message)}
+	result, err := parser.parseText(false, false)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid ICU message at position %d", parser.pos)
+	}
+	if parser.pos < len(parser.input) {
+		return "", errors.Errorf("invalid ICU message: unexpected '}' at position %d", parser.pos)
+	}
+	return result, nil
+}
+
+// parseText reads text until the end of the input or an unmatched `}`.
+// Inside branches (inBranch), arguments are not allowed and `#` is converted to numberMarker.
+func (p *messageParser) parseText(inBranch, inPlural bool) (string, error) {
+	builder := strings.Builder{}
+	lastLiteral := rune(0)
+	writeLiteral := func(r rune) error {
+		if r == '{' && lastLiteral == '#' {
+			return errors.New("literal '#{' cannot be represented in donggu templates")
+		}
+		builder.WriteRune(r)
+		lastLiteral = r
+		return nil
+	}
+
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		switch {
+		case r == '\'':
+			quoted := p.readApostrophe(inPlural)
+			for _, q := range quoted {
+				if err := writeLiteral(q); err != nil {
+					return "", err
+				}
+			}
+		case r == '{':
+			if inBranch {
+				return "", errors.New("nested arguments inside branches are not supported")
+			}
+			p.pos++
+			argument, err := p.parseArgument()
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(argument)
+			lastLiteral = 0
+		case r == '}':
+			return builder.String(), nil
+		case r == '#' && inPlural:
+			p.pos++
+			builder.WriteRune(numberMarker)
+			lastLiteral = 0
+		default:
+			p.pos++
+			if err := writeLiteral(r); err != nil {
+				return "", err
+			}
+		}
+	}
+	return builder.String(), nil
+}
+
+// readApostrophe reads an apostrophe at the current position and returns the literal text it represents.
+func (p *messageParser) readApostrophe(inPlural bool) string {
+	p.pos++
+	if p.pos >= len(p.input) {
+		return "'"
+	}
+	next := p.input[p.pos]
+	if next == '\'' {
+		p.pos++
+		return "'"
+	}
+	if !(next == '{' || next == '}' || next == '|' || (inPlural && next == '#')) {
+		return "'"
+	}
+
+	quoted := strings.Builder{}
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		p.pos++
+		if r == '\'' {
+			if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+				quoted.WriteRune('\'')
+				p.pos++
+				continue
+			}
+			break
+		}
+		quoted.WriteRune(r)
+	}
+	return quoted.String()
+}
+
+func (p *messageParser) parseArgument() (string, error) {
+	p.skipSpace()
+	name := p.readWord()
+	if name == "" {
+		return "", errors.New("argument name expected")
+	}
+	key, err := templateKey(name)
+	if err != nil {
+		return "", err
+	}
+	p.skipSpace()
+	if p.consume('}') {
+		return fmt.Sprintf("#{%s}", key), nil
+	}
+	if !p.consume(',') {
+		return "", errors.Errorf("expected ',' or '}' after argument '%s'", name)
+	}
+
+	p.skipSpace()
+	argType := p.readWord()
+	p.skipSpace()
+	hasStyle := p.consume(',')
+	if !hasStyle && !p.consume('}') {
+		return "", errors.Errorf("expected ',' or '}' after type of argument '%s'", name)
+	}
+
+	switch argType {
+	case "number":
+		style := ""
+		if hasStyle {
+			style, err = p.readStyle()
+			if err != nil {
+				return "", err
+			}
+		}
+		return parseNumberArgument(key, style)
+	case "plural":
+		if !hasStyle {
+			return "", errors.Errorf("plural argument '%s' has no branches", name)
+		}
+		branches, err := p.parseBranches(true)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid plural argument '%s'", name)
+		}
+		return buildPluralTemplate(key, branches)
+	case "select":
+		if !hasStyle {
+			return "", errors.Errorf("select argument '%s' has no branches", name)
+		}
+		branches, err := p.parseBranches(false)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid select argument '%s'", name)
+		}
+		return buildBoolTemplate(key, branches)
+	default:
+		return "", errors.Errorf("argument type '%s' has no donggu equivalent", argType)
+	}
+}
+
+type messageBranch struct {
+	key  string
+	text string
+}
+
+// parseBranches reads `key {text}` pairs until the closing `}` of the argument.
+func (p *messageParser) parseBranches(inPlural bool) ([]messageBranch, error) {
+	branches := []messageBranch{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, errors.New("unterminated argument")
+		}
+		if p.consume('}') {
+			return branches, nil
+		}
+		key := p.readWord()
+		if key == "" {
+			return nil, errors.New("branch key expected")
+		}
+		if strings.HasPrefix(key, "offset:") {
+			return nil, errors.New("plural offsets have no donggu equivalent")
+		}
+		p.skipSpace()
+		if !p.consume('{') {
+			return nil, errors.Errorf("expected '{' after branch '%s'", key)
+		}
+		text, err := p.parseText(true, inPlural)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid branch '%s'", key)
+		}
+		if !p.consume('}') {
+			return nil, errors.Errorf("unterminated branch '%s'", key)
+		}
+		branches = append(branches, messageBranch{key: key, text: text})
+	}
+}
+
+func (p *messageParser) readStyle() (string, error) {
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '}' {
+		if p.input[p.pos] == '{' {
+			return "", errors.New("unexpected '{' in argument style")
+		}
+		p.pos++
+	}
+	if !p.consume('}') {
+		return "", errors.New("unterminated argument style")
+	}
+	return strings.TrimSpace(string(p.input[start : p.pos-1])), nil
+}
+
+func (p *messageParser) readWord() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if unicode.IsSpace(r) || r == ',' || r == '{' || r == '}' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *messageParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *messageParser) consume(r rune) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == r {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// templateKey converts an ICU argument name such as `userName` to a template key such as `USER_NAME`.
+func templateKey(name string) (string, error) {
+	if templateKeyRegex.MatchString(name) {
+		return name, nil
+	}
+	builder := strings.Builder{}
+	runes := []rune(name)
+	for index, r := range runes {
+		if r == '-' || r == '_' {
+			builder.WriteRune('_')
+			continue
+		}
+		if unicode.IsUpper(r) && index > 0 && (unicode.IsLower(runes[index-1]) || unicode.IsDigit(runes[index-1])) {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	key := builder.String()
+	if !templateKeyRegex.MatchString(key) {
+		return "", errors.Errorf("argument name '%s' cannot be converted to a template key", name)
+	}
+	return key, nil
+}
+
+func parseNumberArgument(key, style string) (string, error) {
+	switch {
+	case style == "":
+		return fmt.Sprintf("#{%s|float|,}", key), nil
+	case style == "integer":
+		return fmt.Sprintf("#{%s|int|,}", key), nil
+	case strings.HasPrefix(style, "::"):
+		return parseNumberSkeleton(key, strings.Fields(strings.TrimPrefix(style, "::")))
+	default:
+		return "", errors.Errorf("number style '%s' has no donggu equivalent", style)
+	}
+}
+
+func parseNumberSkeleton(key string, tokens []string) (string, error) {
+	kind := dictionary.FloatTemplateKeyType
+	comma, sign := true, false
+	precision, zeroPadDigits := -1, 0
+
+	for _, token := range tokens {
+		if match := fractionSkeletonRegex.FindStringSubmatch(token); match != nil {
+			precision = len(match[1])
+			continue
+		}
+		if match := integerWidthSkeletonRegex.FindStringSubmatch(token); match != nil {
+			zeroPadDigits = len(match[1])
+			continue
+		}
+		switch token {
+		case "precision-integer":
+			kind = dictionary.IntTemplateKeyType
+		case "group-off":
+			comma = false
+		case "group-auto", "group-min2", "group-on-aligned":
+			comma = true
+		case "sign-always", "+!":
+			sign = true
+		case "sign-auto":
+			sign = false
+		default:
+			return "", errors.Errorf("number skeleton '%s' has no donggu equivalent", token)
+		}
+	}
+
+	option := ""
+	if sign {
+		option += "+"
+	}
+	if zeroPadDigits > 0 {
+		if sign || comma {
+			return "", errors.New("integer width with signs or grouping has no donggu equivalent")
+		}
+		option += "0"
+	}
+	if comma {
+		option += ","
+	}
+	if zeroPadDigits > 0 {
+		width := zeroPadDigits
+		if kind == dictionary.FloatTemplateKeyType && precision > 0 {
+			width += precision + 1
+		}
+		option += fmt.Sprintf("%d", width)
+	}
+	if kind == dictionary.FloatTemplateKeyType && precision >= 0 {
+		option += fmt.Sprintf(".%d", precision)
+	}
+
+	if option == "" {
+		return fmt.Sprintf("#{%s|%s}", key, kind), nil
+	}
+	return fmt.Sprintf("#{%s|%s|%s}", key, kind, option), nil
+}
+
+func buildPluralTemplate(key string, branches []messageBranch) (string, error) {
+	choices := map[string]string{}
+	hasNumber := false
+	for _, branch := range branches {
+		category := dictionary.PluralCategory(branch.key)
+		if _, ok := cldrCategorySet[category]; !ok {
+			return "", errors.Errorf("plural branch '%s' has no donggu equivalent", branch.key)
+		}
+		if _, exists := choices[branch.key]; exists {
+			return "", errors.Errorf("duplicate plural branch '%s'", branch.key)
+		}
+		choices[branch.key] = branch.text
+		if strings.ContainsRune(branch.text, numberMarker) {
+			hasNumber = true
+		}
+	}
+	if _, ok := choices[string(dictionary.OtherPluralCategory)]; !ok {
+		return "", errors.New("plural argument has no 'other' branch")
+	}
+
+	prefix, suffix := "", ""
+	if hasNumber {
+		prefix, suffix = splitCommonAffixes(choices)
+		for category, text := range choices {
+			choices[category] = text[len(prefix) : len(text)-len(suffix)]
+			if strings.ContainsRune(choices[category], numberMarker) {
+				return "", errors.New("'#' can only be used in a part shared by all plural branches")
+			}
+		}
+	}
+
+	categories := make([]string, 0, len(choices))
+	for _, category := range dictionary.PluralCategoryOrder {
+		choice, ok := choices[string(category)]
+		if !ok {
+			continue
+		}
+		if err := checkChoiceText(choice); err != nil {
+			return "", err
+		}
+		categories = append(categories, fmt.Sprintf("%s:%s", category, choice))
+	}
+
+	numberTemplate := fmt.Sprintf("#{%s|int}", key)
+	return strings.ReplaceAll(prefix, string(numberMarker), numberTemplate) +
+		fmt.Sprintf("#{%s|plural|%s}", key, strings.Join(categories, ",")) +
+		strings.ReplaceAll(suffix, string(numberMarker), numberTemplate), nil
+}
+
+// splitCommonAffixes finds the prefix and suffix shared by all branches,
+// cut at word boundaries so that the remaining parts are whole words.
+func splitCommonAffixes(choices map[string]string) (prefix, suffix string) {
+	texts := make([]string, 0, len(choices))
+	for _, text := range choices {
+		texts = append(texts, text)
+	}
+	prefix, suffix = texts[0], texts[0]
+	for _, text := range texts[1:] {
+		for !strings.HasPrefix(text, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+		for !strings.HasSuffix(text, suffix) {
+			suffix = suffix[1:]
+		}
+	}
+	if index := strings.LastIndexFunc(prefix, isAffixBoundary); index != -1 {
+		prefix = prefix[:index+1]
+	} else {
+		prefix = ""
+	}
+	if index := strings.IndexFunc(suffix, isAffixBoundary); index != -1 {
+		suffix = suffix[index:]
+	} else {
+		suffix = ""
+	}
+
+	shortest := len(texts[0])
+	for _, text := range texts {
+		if len(text) < shortest {
+			shortest = len(text)
+		}
+	}
+	if len(prefix)+len(suffix) > shortest {
+		suffix = ""
+	}
+	return
+}
+
+func isAffixBoundary(r rune) bool {
+	return unicode.IsSpace(r) || r == numberMarker
+}
+
+func buildBoolTemplate(key string, branches []messageBranch) (string, error) {
+	values := map[string]string{}
+	for _, branch := range branches {
+		if branch.key != "true" && branch.key != "false" && branch.key != "other" {
+			return "", errors.Errorf("select branch '%s' has no donggu equivalent", branch.key)
+		}
+		if err := checkChoiceText(branch.text); err != nil {
+			return "", err
+		}
+		values[branch.key] = branch.text
+	}
+	trueValue, hasTrue := values["true"]
+	falseValue, hasFalse := values["false"]
+	if !hasFalse {
+		falseValue, hasFalse = values["other"]
+	}
+	if !hasTrue || !hasFalse {
+		return "", errors.New("select arguments should have 'true' and 'false' or 'other' branches")
+	}
+	return fmt.Sprintf("#{%s|bool|%s,%s}", key, trueValue, falseValue), nil
+}
+
+// checkChoiceText checks if text can be used as a choice of a donggu template option.
+func checkChoiceText(text string) error {
+	if strings.ContainsAny(text, ",}") {
+		return errors.Errorf("branch text '%s' cannot contain ',' or '}'", text)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// selfEntryNames are the names of members holding the fields of their parent in the nested form,
+// for entries which also have children. They match the names of self entries in generated code.
+var selfEntryNames = map[string]bool{"$": true, "_": true}
+
+// nestedChildKey returns the key of a member of key in the nested form.
+// Self entry members are the entry of key itself, unless key is the root.
+func nestedChildKey(key dictionary.EntryKey, name string) dictionary.EntryKey {
+	if key != "" && selfEntryNames[name] {
+		return key
+	}
+	return key.NewChild(name)
+}
+
+// contentAssembler assembles content from fields of entries, which may be spread over nested objects.
+// Fields defined more than once are reported instead of being overwritten.
+type contentAssembler struct {
+	content     dictionary.FlattenedContent
+	positions   dictionary.ContentPositions
+	diagnostics dictionary.Diagnostics
+}
+
+func newContentAssembler() *contentAssembler {
+	return &contentAssembler{
+		content:   dictionary.FlattenedContent{},
+		positions: dictionary.ContentPositions{},
+	}
+}
+
+// AddField adds a field of an entry. keyPosition is used as the position of the entry
+// if the entry does not exist yet.
+func (c *contentAssembler) AddField(
+	key dictionary.EntryKey, keyPosition dictionary.SourcePosition,
+	field, text string, fieldPosition, valuePosition dictionary.SourcePosition,
+) {
+	if key == "" {
+		c.AddError(fieldPosition, fmt.Sprintf("field '%s' does not belong to any entry", field))
+		return
+	}
+	position, ok := c.positions[key]
+	if !ok {
+		position = dictionary.EntryPosition{
+			Key:    keyPosition,
+			Fields: map[string]dictionary.SourcePosition{},
+			Values: map[string]dictionary.SourcePosition{},
+		}
+		c.positions[key] = position
+		c.content[key] = dictionary.Entry{}
+	}
+	if previous, ok := position.Fields[field]; ok {
+		message := fmt.Sprintf("duplicate field '%s'", field)
+		if previous.Line > 0 {
+			message += fmt.Sprintf(", first defined at %s", previous)
+		}
+		c.diagnostics = append(c.diagnostics, dictionary.Diagnostic{
+			Code:     dictionary.CodeDuplicateKey,
+			Severity: dictionary.SeverityError,
+			Message:  message,
+			Key:      key,
+			Language: field,
+			Position: fieldPosition,
+		})
+		return
+	}
+	c.content[key][field] = text
+	position.Fields[field] = fieldPosition
+	position.Values[field] = valuePosition
+}
+
+// AddError adds an error which is not specific to an entry.
+func (c *contentAssembler) AddError(position dictionary.SourcePosition, message string) {
+	c.diagnostics = append(c.diagnostics, dictionary.Diagnostic{
+		Code:     dictionary.CodeInvalidSyntax,
+		Severity: dictionary.SeverityError,
+		Message:  message,
+		Position: position,
+	})
+}
+
+// Result returns the assembled content. Errors are returned as dictionary.DiagnosticsError.
+func (c *contentAssembler) Result() (*dictionary.FlattenedContent, dictionary.ContentPositions, error) {
+	if len(c.diagnostics) > 0 {
+		return &dictionary.FlattenedContent{}, nil, dictionary.DiagnosticsError{Diagnostics: c.diagnostics}
+	}
+	return &c.content, c.positions, nil
+}
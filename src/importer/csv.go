@@ -13,7 +13,6 @@ import (
 
 const keyColumnName = "key"
 const indexColumnName = "index"
-const contextColumnName = "context"
 
 type CsvDictionaryImporter struct{}
 
@@ -41,7 +40,7 @@ func (c CsvDictionaryImporter) ImportContent(file io.Reader, metadata dictionary
 		col = strings.ToLower(col)
 		header[index] = col
 		_, isLanguage := langSet[col]
-		if !isLanguage && col != contextColumnName && col != indexColumnName && col != keyColumnName {
+		if !isLanguage && !dictionary.IsEntryMetaField(col) && col != indexColumnName && col != keyColumnName {
 			return &dictionary.FlattenedContent{}, errors.Errorf("invalid header '%s' at index %d", col, index)
 		}
 	}
@@ -64,6 +63,10 @@ func (c CsvDictionaryImporter) ImportContent(file io.Reader, metadata dictionary
 			if colName == keyColumnName {
 				keyName = col
 			} else if colName != indexColumnName {
+				// Empty cells are written by the exporter for missing fields.
+				if col == "" {
+					continue
+				}
 				entry[colName] = col
 			}
 		}
@@ -0,0 +1,44 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/maasasia/donggu/icu"
+	"github.com/pkg/errors"
+)
+
+// IcuDictionaryImporter reads content in the same shape as content.json,
+// with each text written in ICU MessageFormat.
+type IcuDictionaryImporter struct{}
+
+func (i IcuDictionaryImporter) ImportContent(file io.Reader, _ dictionary.Metadata) (dictionary.ContentRepresentation, error) {
+	decoder := json.NewDecoder(file)
+	decoded := jsonContentType{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return &dictionary.FlattenedContent{}, errors.Wrap(err, "failed to decode JSON")
+	}
+
+	result := dictionary.FlattenedContent{}
+	for entryKey, entry := range decoded {
+		converted := dictionary.Entry{}
+		for lang, value := range entry {
+			if dictionary.IsEntryMetaField(lang) {
+				converted[lang] = value
+				continue
+			}
+			template, err := icu.ParseMessage(value)
+			if err != nil {
+				return &dictionary.FlattenedContent{}, errors.Wrapf(err, "failed to convert '%s' (%s)", entryKey, lang)
+			}
+			converted[lang] = template
+		}
+		result[dictionary.EntryKey(entryKey)] = converted
+	}
+	return &result, nil
+}
+
+func (i IcuDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
+	return dictionary.Metadata{}, errors.New("unsupported")
+}
@@ -21,6 +21,13 @@ type DictionaryFileImporter interface {
 	ImportMetadata(r io.Reader) (dictionary.Metadata, error)
 }
 
+// ContentPositionImporter is a DictionaryFileImporter which can record the positions of entries in the file.
+type ContentPositionImporter interface {
+	// ImportContentWithPositions is same as ImportContent, but also returns the positions of entries in the file.
+	// fileName is used as the file of the positions.
+	ImportContentWithPositions(r io.Reader, fileName string) (dictionary.ContentRepresentation, dictionary.ContentPositions, error)
+}
+
 type DictionaryProjectImporter interface {
 	// OpenMetadataFile creates a ReadCloser that reads metadata from the given project.
 	// Usually this would be an os.File.
@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"os"
@@ -16,8 +17,9 @@ import (
 var pluralOperatorRegex = regexp.MustCompile(`^([<>]=?|==)|(?:(%|\/)([1-9]\d*))$`)
 
 type jsonPluralDefinition struct {
-	Op    string `json:"op"`
-	Value int    `json:"value"`
+	Category string `json:"category"`
+	Op       string `json:"op"`
+	Value    int    `json:"value"`
 }
 
 func (j jsonPluralDefinition) Parse() (dictionary.PluralDefinition, error) {
@@ -32,6 +34,7 @@ func (j jsonPluralDefinition) Parse() (dictionary.PluralDefinition, error) {
 			return dictionary.PluralDefinition{}, errors.Errorf("'%s' is an invalid operand", operandStr)
 		}
 		return dictionary.PluralDefinition{
+			Category:   dictionary.PluralCategory(j.Category),
 			Op:         match[0][2],
 			Operand:    int(operand),
 			HasOperand: true,
@@ -39,19 +42,60 @@ func (j jsonPluralDefinition) Parse() (dictionary.PluralDefinition, error) {
 		}, nil
 	} else {
 		return dictionary.PluralDefinition{
-			Op:     match[0][1],
-			Equals: j.Value,
+			Category: dictionary.PluralCategory(j.Category),
+			Op:       match[0][1],
+			Equals:   j.Value,
 		}, nil
 	}
 }
 
+type jsonLintConfig struct {
+	SourceLanguage string                            `json:"source_language"`
+	Rules          map[string]map[string]interface{} `json:"rules"`
+}
+
+// Parse converts the lint configuration. The key 'severity' of a rule is its severity,
+// and other keys are rule specific options.
+func (j jsonLintConfig) Parse() (dictionary.LintConfig, error) {
+	result := dictionary.LintConfig{
+		SourceLanguage: j.SourceLanguage,
+		Rules:          map[string]dictionary.LintRuleConfig{},
+	}
+	for name, rule := range j.Rules {
+		converted := dictionary.LintRuleConfig{Options: map[string]interface{}{}}
+		for key, value := range rule {
+			if key != "severity" {
+				converted.Options[key] = value
+				continue
+			}
+			severity, ok := value.(string)
+			if !ok {
+				return dictionary.LintConfig{}, errors.Errorf("severity of rule '%s' should be a string", name)
+			}
+			converted.Severity = dictionary.Severity(severity)
+		}
+		result.Rules[name] = converted
+	}
+	return result, nil
+}
+
+// cldrPluralValue is the plural definition value for using plural rules from CLDR data.
+const cldrPluralValue = "cldr"
+
 type jsonContentType map[string]map[string]string
 type jsonMetadataType struct {
 	Version            string                            `json:"version"`
 	RequiredLanguages  []string                          `json:"required_languages"`
 	SupportedLanguages []string                          `json:"supported_languages"`
 	ExporterOptions    map[string]map[string]interface{} `json:"exporter_options"`
-	Plurals            map[string][]jsonPluralDefinition `json:"plurals"`
+	Plurals            map[string]json.RawMessage        `json:"plurals"`
+	Lint               jsonLintConfig                    `json:"lint"`
+	ContentLayout      jsonContentLayout                 `json:"content_layout"`
+}
+
+type jsonContentLayout struct {
+	Type      string `json:"type"`
+	Directory string `json:"directory"`
 }
 
 type JsonDictionaryImporter struct{}
@@ -65,16 +109,33 @@ func (j JsonDictionaryImporter) OpenContentFile(projectRoot string) (io.ReadClos
 }
 
 func (j JsonDictionaryImporter) ImportContent(file io.Reader, _ dictionary.Metadata) (dictionary.ContentRepresentation, error) {
-	decoder := json.NewDecoder(file)
-	decoded := jsonContentType{}
-	if err := decoder.Decode(&decoded); err != nil {
-		return &dictionary.FlattenedContent{}, errors.Wrap(err, "failed to decode JSON")
+	content, _, err := j.ImportContentWithPositions(file, "")
+	return content, err
+}
+
+// ImportContentWithPositions is same as ImportContent, but also returns the positions of entries in the file.
+// fileName is used as the file of the positions.
+//
+// Duplicate keys and syntax errors are returned as dictionary.DiagnosticsError with their positions.
+func (j JsonDictionaryImporter) ImportContentWithPositions(file io.Reader, fileName string) (
+	dictionary.ContentRepresentation, dictionary.ContentPositions, error,
+) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return &dictionary.FlattenedContent{}, nil, errors.Wrap(err, "failed to read file")
 	}
-	result := dictionary.FlattenedContent{}
-	for entryKey, entry := range decoded {
-		result[dictionary.EntryKey(entryKey)] = dictionary.Entry(entry)
+	decoder := newJsonContentDecoder()
+	decoder.DecodeEntries(data, fileName, func(dictionary.EntryKey) string { return "" })
+	return decoder.Result()
+}
+
+// importMetadataValue imports metadata decoded from other formats, which has the same shape as metadata.json.
+func importMetadataValue(value interface{}) (dictionary.Metadata, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return dictionary.Metadata{}, errors.Wrap(err, "invalid metadata")
 	}
-	return &result, nil
+	return JsonDictionaryImporter{}.ImportMetadata(bytes.NewReader(encoded))
 }
 
 func (j JsonDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
@@ -92,8 +153,30 @@ func (j JsonDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metad
 		return dictionary.Metadata{}, errors.New("version missing")
 	}
 	result.ExporterOptions = decoded.ExporterOptions
+	lint, err := decoded.Lint.Parse()
+	if err != nil {
+		return dictionary.Metadata{}, errors.Wrap(err, "invalid lint configuration")
+	}
+	result.Lint = lint
+	result.ContentLayout = dictionary.ContentLayout{
+		Type:      dictionary.ContentLayoutType(decoded.ContentLayout.Type),
+		Directory: decoded.ContentLayout.Directory,
+	}
 	result.Plurals = map[string][]dictionary.PluralDefinition{}
-	for lang, defs := range decoded.Plurals {
+	result.CldrPlurals = map[string]struct{}{}
+	for lang, rawDefs := range decoded.Plurals {
+		var cldrValue string
+		if err := json.Unmarshal(rawDefs, &cldrValue); err == nil {
+			if cldrValue != cldrPluralValue {
+				return dictionary.Metadata{}, errors.Errorf("invalid plural definition for '%s': expected '%s' or a list", lang, cldrPluralValue)
+			}
+			result.CldrPlurals[lang] = struct{}{}
+			continue
+		}
+		var defs []jsonPluralDefinition
+		if err := json.Unmarshal(rawDefs, &defs); err != nil {
+			return dictionary.Metadata{}, errors.Wrapf(err, "invalid plural definition for '%s'", lang)
+		}
 		result.Plurals[lang] = make([]dictionary.PluralDefinition, 0, len(defs))
 		for index, def := range defs {
 			converted, err := def.Parse()
@@ -0,0 +1,297 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// jsonContentDecoder decodes content JSON files token by token, recording the position of every key and value.
+// Unlike encoding/json, duplicate keys are reported instead of being overwritten.
+//
+// Content may be either flat, keyed by entry keys, or nested by the parts of entry keys.
+// Strings are fields of the entry at their path and objects are subtrees,
+// so an object with languages or context is an entry. Members named `$` or `_` hold the fields of
+// their parent, for entries which also have children.
+//
+// Entries of all files decoded are assembled into a single content.
+type jsonContentDecoder struct {
+	*contentAssembler
+}
+
+// jsonContentFileDecoder decodes a single file for jsonContentDecoder.
+type jsonContentFileDecoder struct {
+	*jsonContentDecoder
+	data       []byte
+	fileName   string
+	lineStarts []int
+	decoder    *json.Decoder
+}
+
+func newJsonContentDecoder() *jsonContentDecoder {
+	return &jsonContentDecoder{contentAssembler: newContentAssembler()}
+}
+
+// DecodeEntries decodes a file with the shape of content.json.
+// fileOf returns the expected file of an entry key, or an empty string if any file is allowed.
+// Decoding stops at the first error which is not a duplicate or misplaced key.
+func (j *jsonContentDecoder) DecodeEntries(data []byte, fileName string, fileOf func(dictionary.EntryKey) string) {
+	if err := j.file(data, fileName).decodeEntries(fileOf); err != nil {
+		j.diagnostics = append(j.diagnostics, *err)
+	}
+}
+
+// DecodeField decodes a file mapping entry keys to the text of a single field, such as a language.
+// Decoding stops at the first error which is not a duplicate key.
+func (j *jsonContentDecoder) DecodeField(data []byte, fileName string, field string) {
+	if err := j.file(data, fileName).decodeField(field); err != nil {
+		j.diagnostics = append(j.diagnostics, *err)
+	}
+}
+
+func (j *jsonContentDecoder) file(data []byte, fileName string) *jsonContentFileDecoder {
+	lineStarts := []int{0}
+	for index, char := range data {
+		if char == '\n' {
+			lineStarts = append(lineStarts, index+1)
+		}
+	}
+	return &jsonContentFileDecoder{
+		jsonContentDecoder: j,
+		data:               data,
+		fileName:           fileName,
+		lineStarts:         lineStarts,
+		decoder:            json.NewDecoder(bytes.NewReader(data)),
+	}
+}
+
+func (j *jsonContentFileDecoder) decodeEntries(fileOf func(dictionary.EntryKey) string) *dictionary.Diagnostic {
+	start, token, err := j.next()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return j.invalidSyntax(start, "content should be an object")
+	}
+	return j.decodeEntryMembers("", dictionary.SourcePosition{}, fileOf)
+}
+
+// decodeEntryMembers decodes the members of an object at key, after its opening brace.
+func (j *jsonContentFileDecoder) decodeEntryMembers(
+	key dictionary.EntryKey, keyPosition dictionary.SourcePosition, fileOf func(dictionary.EntryKey) string,
+) *dictionary.Diagnostic {
+	defined := map[string]dictionary.SourcePosition{}
+	for j.decoder.More() {
+		nameStart, token, err := j.next()
+		if err != nil {
+			return err
+		}
+		name := token.(string)
+		namePosition := j.position(nameStart)
+		childKey := nestedChildKey(key, name)
+		if key == "" {
+			if expected := fileOf(childKey); expected != "" && expected != j.fileName {
+				j.diagnostics = append(j.diagnostics, dictionary.Diagnostic{
+					Code:     dictionary.CodeInvalidKey,
+					Severity: dictionary.SeverityError,
+					Message:  fmt.Sprintf("key should be in '%s'", expected),
+					Key:      childKey,
+					Position: namePosition,
+				})
+			}
+		}
+
+		valueStart, value, err := j.next()
+		if err != nil {
+			return err
+		}
+		if previous, ok := defined[name]; ok {
+			duplicate := dictionary.Diagnostic{
+				Code:     dictionary.CodeDuplicateKey,
+				Severity: dictionary.SeverityError,
+				Message:  fmt.Sprintf("duplicate key, first defined at %s", previous),
+				Key:      childKey,
+				Position: namePosition,
+			}
+			if _, isField := value.(string); isField && key != "" {
+				duplicate.Message = fmt.Sprintf("duplicate field '%s', first defined at %s", name, previous)
+				duplicate.Key, duplicate.Language = key, name
+			}
+			j.diagnostics = append(j.diagnostics, duplicate)
+			if err := j.skip(value); err != nil {
+				return err
+			}
+			continue
+		}
+		defined[name] = namePosition
+
+		switch value := value.(type) {
+		case string:
+			if key == "" {
+				return j.invalidSyntax(valueStart, fmt.Sprintf("entry '%s' should be an object", name))
+			}
+			j.AddField(key, keyPosition, name, value, namePosition, j.position(valueStart))
+		case json.Delim:
+			if value != '{' {
+				return j.invalidSyntax(valueStart, fmt.Sprintf("value of '%s' should be a string or an object", key.NewChild(name)))
+			}
+			childPosition := namePosition
+			if childKey == key {
+				childPosition = keyPosition
+			}
+			if err := j.decodeEntryMembers(childKey, childPosition, fileOf); err != nil {
+				return err
+			}
+		default:
+			if key == "" {
+				return j.invalidSyntax(valueStart, fmt.Sprintf("entry '%s' should be an object", name))
+			}
+			return j.invalidSyntax(valueStart, fmt.Sprintf("field '%s' of entry '%s' should be a string", name, key))
+		}
+	}
+	_, _, err := j.next()
+	return err
+}
+
+func (j *jsonContentFileDecoder) decodeField(field string) *dictionary.Diagnostic {
+	start, token, err := j.next()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return j.invalidSyntax(start, "content should be an object")
+	}
+	return j.decodeFieldMembers("", dictionary.SourcePosition{}, field)
+}
+
+// decodeFieldMembers decodes the members of an object at key for a single field, after its opening brace.
+// Strings are the texts of the entries at their path, and objects are subtrees.
+func (j *jsonContentFileDecoder) decodeFieldMembers(
+	key dictionary.EntryKey, keyPosition dictionary.SourcePosition, field string,
+) *dictionary.Diagnostic {
+	defined := map[string]dictionary.SourcePosition{}
+	for j.decoder.More() {
+		nameStart, token, err := j.next()
+		if err != nil {
+			return err
+		}
+		name := token.(string)
+		namePosition := j.position(nameStart)
+		entryKey := nestedChildKey(key, name)
+		entryPosition := namePosition
+		if entryKey == key {
+			entryPosition = keyPosition
+		}
+
+		valueStart, value, err := j.next()
+		if err != nil {
+			return err
+		}
+		if previous, ok := defined[name]; ok {
+			j.diagnostics = append(j.diagnostics, dictionary.Diagnostic{
+				Code:     dictionary.CodeDuplicateKey,
+				Severity: dictionary.SeverityError,
+				Message:  fmt.Sprintf("duplicate key, first defined at %s", previous),
+				Key:      entryKey,
+				Language: field,
+				Position: namePosition,
+			})
+			if err := j.skip(value); err != nil {
+				return err
+			}
+			continue
+		}
+		defined[name] = namePosition
+
+		switch value := value.(type) {
+		case string:
+			j.AddField(entryKey, entryPosition, field, value, namePosition, j.position(valueStart))
+		case json.Delim:
+			if value != '{' {
+				return j.invalidSyntax(valueStart, fmt.Sprintf("text of entry '%s' should be a string", entryKey))
+			}
+			if err := j.decodeFieldMembers(entryKey, entryPosition, field); err != nil {
+				return err
+			}
+		default:
+			return j.invalidSyntax(valueStart, fmt.Sprintf("text of entry '%s' should be a string", entryKey))
+		}
+	}
+	_, _, err := j.next()
+	return err
+}
+
+// skip skips the rest of a value whose first token is token.
+func (j *jsonContentFileDecoder) skip(token json.Token) *dictionary.Diagnostic {
+	if token != json.Delim('{') && token != json.Delim('[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		_, token, err := j.next()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// next reads the next token, and returns it with its offset.
+func (j *jsonContentFileDecoder) next() (int, json.Token, *dictionary.Diagnostic) {
+	start := j.tokenStart(int(j.decoder.InputOffset()))
+	token, err := j.decoder.Token()
+	if err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			// Offset is right after the invalid character.
+			return start, nil, j.invalidSyntax(int(syntaxErr.Offset)-1, syntaxErr.Error())
+		}
+		return start, nil, j.invalidSyntax(start, err.Error())
+	}
+	return start, token, nil
+}
+
+// tokenStart returns the offset of the token following offset, by skipping whitespace and separators.
+func (j *jsonContentFileDecoder) tokenStart(offset int) int {
+	for offset < len(j.data) {
+		switch j.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// position converts a byte offset to a position. The column is counted in characters.
+func (j *jsonContentFileDecoder) position(offset int) dictionary.SourcePosition {
+	if offset > len(j.data) {
+		offset = len(j.data)
+	} else if offset < 0 {
+		offset = 0
+	}
+	line := sort.Search(len(j.lineStarts), func(i int) bool { return j.lineStarts[i] > offset }) - 1
+	return dictionary.SourcePosition{
+		File:   j.fileName,
+		Line:   line + 1,
+		Column: utf8.RuneCount(j.data[j.lineStarts[line]:offset]) + 1,
+	}
+}
+
+func (j *jsonContentFileDecoder) invalidSyntax(offset int, message string) *dictionary.Diagnostic {
+	return &dictionary.Diagnostic{
+		Code:     dictionary.CodeInvalidSyntax,
+		Severity: dictionary.SeverityError,
+		Message:  message,
+		Position: j.position(offset),
+	}
+}
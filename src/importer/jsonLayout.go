@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// ImportProjectContent imports content of a project, assembling the files of the content layout.
+// filePath converts paths relative to the project root to the file names used in positions,
+// and may be nil to use them as is.
+//
+// Duplicate keys, keys in wrong files and syntax errors are returned as dictionary.DiagnosticsError.
+func (j JsonDictionaryImporter) ImportProjectContent(
+	projectRoot string,
+	layout dictionary.ContentLayout,
+	filePath func(string) string,
+) (dictionary.ContentRepresentation, dictionary.ContentPositions, error) {
+	if filePath == nil {
+		filePath = func(name string) string { return name }
+	}
+	if layout.IsSingle() {
+		file, err := j.OpenContentFile(projectRoot)
+		if err != nil {
+			return &dictionary.FlattenedContent{}, nil, errors.Wrap(err, "failed to open content file")
+		}
+		defer file.Close()
+		return j.ImportContentWithPositions(file, filePath(layout.Path()))
+	}
+
+	names, err := layoutFileNames(projectRoot, layout)
+	if err != nil {
+		return &dictionary.FlattenedContent{}, nil, err
+	}
+	decoder := newJsonContentDecoder()
+	for _, name := range names {
+		relPath := layout.FilePath(name)
+		data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(relPath)))
+		if err != nil {
+			return &dictionary.FlattenedContent{}, nil, errors.Wrapf(err, "failed to read content file '%s'", relPath)
+		}
+		switch layout.Type {
+		case dictionary.ContentLayoutKey:
+			decoder.DecodeEntries(data, filePath(relPath), func(key dictionary.EntryKey) string {
+				return filePath(layout.FilePath(key.TopLevel()))
+			})
+		case dictionary.ContentLayoutLanguage:
+			decoder.DecodeField(data, filePath(relPath), name)
+		}
+	}
+	return decoder.Result()
+}
+
+// layoutFileNames returns the names of the JSON files in the directory of a layout, without extensions.
+// A directory which does not exist yet has no files.
+func layoutFileNames(projectRoot string, layout dictionary.ContentLayout) ([]string, error) {
+	directory := filepath.Join(projectRoot, filepath.FromSlash(layout.ContentDirectory()))
+	dirEntries, err := os.ReadDir(directory)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read content directory '%s'", layout.ContentDirectory())
+	}
+	names := []string{}
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || path.Ext(dirEntry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(dirEntry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// multiFileReader reads multiple files in order, separated by blank lines.
+type multiFileReader struct {
+	io.Reader
+	files []*os.File
+}
+
+// openDirectoryFiles opens all files in directory matching any of the patterns as a single stream.
+// Files are read in the order of their names.
+func openDirectoryFiles(directory string, patterns ...string) (io.ReadCloser, error) {
+	paths := []string{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(directory, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("no files matching '%s' in '%s'", strings.Join(patterns, "', '"), directory)
+	}
+	sort.Strings(paths)
+
+	files := make([]*os.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			newMultiFileReader(files).Close()
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return newMultiFileReader(files), nil
+}
+
+func newMultiFileReader(files []*os.File) *multiFileReader {
+	readers := make([]io.Reader, 0, len(files)*2)
+	for _, file := range files {
+		readers = append(readers, file, strings.NewReader("\n\n"))
+	}
+	return &multiFileReader{Reader: io.MultiReader(readers...), files: files}
+}
+
+func (m *multiFileReader) Close() error {
+	var errs *multierror.Error
+	for _, file := range m.files {
+		if err := file.Close(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs.ErrorOrNil()
+}
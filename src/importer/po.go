@@ -0,0 +1,285 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var poPluralKeyRegex = regexp.MustCompile(`^#{([A-Z0-9_]+)}$`)
+var poMsgstrIndexRegex = regexp.MustCompile(`^msgstr\[(\d+)\]$`)
+
+// PoDictionaryImporter reads GNU gettext catalogs written by exporter.PoDictionaryExporter.
+//
+// The language of each entry is taken from the Language field of the preceding header entry,
+// so a stream may contain catalogs of several languages. Fuzzy translations are ignored.
+type PoDictionaryImporter struct{}
+
+type poEntry struct {
+	comments []string
+	fuzzy    bool
+	msgctxt  *string
+	msgid    string
+	plural   *string
+	msgstr   []string
+}
+
+func (p PoDictionaryImporter) OpenMetadataFile(projectRoot string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (p PoDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
+	return dictionary.Metadata{}, errors.New("unsupported")
+}
+
+// OpenContentFile opens all PO files in projectRoot as a single stream.
+func (p PoDictionaryImporter) OpenContentFile(projectRoot string) (io.ReadCloser, error) {
+	return openDirectoryFiles(projectRoot, "*.po")
+}
+
+func (p PoDictionaryImporter) ImportContent(file io.Reader, metadata dictionary.Metadata) (dictionary.ContentRepresentation, error) {
+	entries, err := parsePoEntries(file)
+	if err != nil {
+		return &dictionary.FlattenedContent{}, err
+	}
+
+	langSet := metadata.SupportedLanguageSet()
+	result := dictionary.FlattenedContent{}
+	lang := ""
+	for _, entry := range entries {
+		if entry.msgid == "" && entry.msgctxt == nil {
+			lang = poHeaderField(entry.msgstr, "Language")
+			if _, ok := langSet[lang]; !ok {
+				return &dictionary.FlattenedContent{}, errors.Errorf("unsupported language '%s' in PO header", lang)
+			}
+			continue
+		}
+		if lang == "" {
+			return &dictionary.FlattenedContent{}, errors.Errorf("entry '%s' is not preceded by a PO header", entry.msgid)
+		}
+
+		key := dictionary.EntryKey(entry.msgid)
+		converted, ok := result[key]
+		if !ok {
+			converted = dictionary.Entry{}
+			result[key] = converted
+		}
+		if len(entry.comments) > 0 {
+			converted["context"] = strings.Join(entry.comments, "\n")
+		}
+		if entry.fuzzy {
+			continue
+		}
+
+		value := ""
+		if entry.plural == nil {
+			value = entry.msgstr[0]
+		} else if value, err = poPluralTemplate(entry, lang, metadata); err != nil {
+			return &dictionary.FlattenedContent{}, errors.Wrapf(err, "invalid plural entry '%s' (%s)", key, lang)
+		}
+		if value == "" {
+			continue
+		}
+		if _, exists := converted[lang]; exists {
+			return &dictionary.FlattenedContent{}, errors.Errorf("duplicate key '%s' (%s)", key, lang)
+		}
+		converted[lang] = value
+	}
+	return &result, nil
+}
+
+func parsePoEntries(file io.Reader) ([]poEntry, error) {
+	entries := []poEntry{}
+	current := poEntry{}
+	// target is the string continuation lines are appended to.
+	var target *string
+	hasContent := false
+
+	flush := func() {
+		if hasContent {
+			entries = append(entries, current)
+		}
+		current = poEntry{}
+		target = nil
+		hasContent = false
+	}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if len(current.msgstr) > 0 {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#~"):
+				// Obsolete entries are ignored.
+			case strings.HasPrefix(line, "#."):
+				current.comments = append(current.comments, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					if strings.TrimSpace(flag) == "fuzzy" {
+						current.fuzzy = true
+					}
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(line, `"`) {
+			if target == nil {
+				return nil, errors.Errorf("unexpected string at line %d", lineNumber)
+			}
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, errors.Errorf("invalid string at line %d", lineNumber)
+			}
+			*target += value
+			continue
+		}
+
+		keyword, quoted := line, ""
+		if index := strings.IndexFunc(line, unicode.IsSpace); index != -1 {
+			keyword, quoted = line[:index], strings.TrimSpace(line[index:])
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, errors.Errorf("invalid string at line %d", lineNumber)
+		}
+		if (keyword == "msgctxt" || keyword == "msgid") && len(current.msgstr) > 0 {
+			flush()
+		}
+		hasContent = true
+
+		switch {
+		case keyword == "msgctxt":
+			current.msgctxt = &value
+			target = current.msgctxt
+		case keyword == "msgid":
+			current.msgid = value
+			target = &current.msgid
+		case keyword == "msgid_plural":
+			current.plural = &value
+			target = current.plural
+		case keyword == "msgstr":
+			current.msgstr = append(current.msgstr, value)
+			target = &current.msgstr[len(current.msgstr)-1]
+		case poMsgstrIndexRegex.MatchString(keyword):
+			index, _ := strconv.Atoi(poMsgstrIndexRegex.FindStringSubmatch(keyword)[1])
+			if index != len(current.msgstr) {
+				return nil, errors.Errorf("unexpected plural index %d at line %d", index, lineNumber)
+			}
+			current.msgstr = append(current.msgstr, value)
+			target = &current.msgstr[len(current.msgstr)-1]
+		default:
+			return nil, errors.Errorf("unknown keyword '%s' at line %d", keyword, lineNumber)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "error while reading file")
+	}
+	flush()
+
+	var errs *multierror.Error
+	for _, entry := range entries {
+		if len(entry.msgstr) == 0 {
+			errs = multierror.Append(errs, errors.Errorf("entry '%s' has no msgstr", entry.msgid))
+		}
+	}
+	return entries, errs.ErrorOrNil()
+}
+
+func poHeaderField(msgstr []string, field string) string {
+	for _, line := range strings.Split(msgstr[0], "\n") {
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(name) == field {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// poPluralTemplate joins the plural forms of an entry to a text with a plural template.
+// Parts shared by all forms are kept outside of the template.
+func poPluralTemplate(entry poEntry, lang string, metadata dictionary.Metadata) (string, error) {
+	forms := entry.msgstr
+	allSame, allEmpty := true, true
+	for _, form := range forms {
+		allSame = allSame && form == forms[0]
+		allEmpty = allEmpty && form == ""
+	}
+	if allEmpty {
+		return "", nil
+	}
+	if allSame {
+		return forms[0], nil
+	}
+
+	match := poPluralKeyRegex.FindStringSubmatch(*entry.plural)
+	if match == nil {
+		return "", errors.Errorf("msgid_plural '%s' is not a template key", *entry.plural)
+	}
+	categories := metadata.PluralCategories(lang)
+	if len(forms) != len(categories) {
+		return "", errors.Errorf("expected %d plural forms, got %d", len(categories), len(forms))
+	}
+
+	prefix, suffix := poCommonAffixes(forms)
+	otherForm := forms[len(forms)-1]
+	choices := make([]string, 0, len(forms))
+	for index, form := range forms {
+		// Categories without a choice fall back to "other".
+		if form == otherForm && categories[index] != dictionary.OtherPluralCategory {
+			continue
+		}
+		choice := form[len(prefix) : len(form)-len(suffix)]
+		if strings.ContainsAny(choice, ",}") || strings.Contains(choice, "#{") {
+			return "", errors.Errorf("plural form '%s' cannot be converted to a template", form)
+		}
+		choices = append(choices, fmt.Sprintf("%s:%s", categories[index], choice))
+	}
+	return fmt.Sprintf("%s#{%s|plural|%s}%s", prefix, match[1], strings.Join(choices, ","), suffix), nil
+}
+
+// poCommonAffixes finds the prefix and suffix shared by all forms, cut at whitespaces.
+func poCommonAffixes(forms []string) (prefix, suffix string) {
+	prefix, suffix = forms[0], forms[0]
+	shortest := len(forms[0])
+	for _, form := range forms[1:] {
+		for !strings.HasPrefix(form, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+		for !strings.HasSuffix(form, suffix) {
+			suffix = suffix[1:]
+		}
+		if len(form) < shortest {
+			shortest = len(form)
+		}
+	}
+	if index := strings.LastIndexFunc(prefix, unicode.IsSpace); index != -1 {
+		prefix = prefix[:index+1]
+	} else {
+		prefix = ""
+	}
+	if index := strings.IndexFunc(suffix, unicode.IsSpace); index != -1 {
+		suffix = suffix[index:]
+	} else {
+		suffix = ""
+	}
+	if len(prefix)+len(suffix) > shortest {
+		suffix = ""
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// TomlDictionaryImporter imports content and metadata written in TOML.
+//
+// Content may be either flat, with a table for each entry key such as `["screens.title"]`,
+// or nested by the parts of entry keys such as `[screens.title]`.
+// In both forms, strings are fields of the entry at their path and tables are subtrees.
+// Tables named `$` or `_` hold the fields of their parent.
+type TomlDictionaryImporter struct{}
+
+func (t TomlDictionaryImporter) OpenMetadataFile(projectRoot string) (io.ReadCloser, error) {
+	return os.OpenFile(path.Join(projectRoot, "metadata.toml"), os.O_RDONLY, 0)
+}
+
+func (t TomlDictionaryImporter) OpenContentFile(projectRoot string) (io.ReadCloser, error) {
+	return os.OpenFile(path.Join(projectRoot, "content.toml"), os.O_RDONLY, 0)
+}
+
+func (t TomlDictionaryImporter) ImportContent(file io.Reader, _ dictionary.Metadata) (dictionary.ContentRepresentation, error) {
+	decoded := map[string]interface{}{}
+	if _, err := toml.NewDecoder(file).Decode(&decoded); err != nil {
+		return &dictionary.FlattenedContent{}, errors.Wrap(err, "failed to decode TOML")
+	}
+	assembler := newContentAssembler()
+	t.walk(assembler, "", decoded)
+	content, _, err := assembler.Result()
+	return content, err
+}
+
+func (t TomlDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
+	decoded := map[string]interface{}{}
+	if _, err := toml.NewDecoder(file).Decode(&decoded); err != nil {
+		return dictionary.Metadata{}, errors.Wrap(err, "failed to decode TOML")
+	}
+	return importMetadataValue(decoded)
+}
+
+func (t TomlDictionaryImporter) walk(assembler *contentAssembler, key dictionary.EntryKey, table map[string]interface{}) {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch value := table[name].(type) {
+		case map[string]interface{}:
+			t.walk(assembler, nestedChildKey(key, name), value)
+		case string:
+			noPosition := dictionary.SourcePosition{}
+			assembler.AddField(key, noPosition, name, value, noPosition, noPosition)
+		default:
+			assembler.AddError(dictionary.SourcePosition{}, fmt.Sprintf("value of '%s' should be a string or a table", key.NewChild(name)))
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package importer
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// XliffDictionaryImporter reads XLIFF 1.2 and 2.0 documents written by exporter.XliffDictionaryExporter.
+// Both the sources and targets of units are imported, and notes are imported as contexts.
+type XliffDictionaryImporter struct{}
+
+type xliffDocument struct {
+	Version string      `xml:"version,attr"`
+	SrcLang string      `xml:"srcLang,attr"`
+	TrgLang string      `xml:"trgLang,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	SourceLanguage string        `xml:"source-language,attr"`
+	TargetLanguage string        `xml:"target-language,attr"`
+	TransUnits     []xliff12Unit `xml:"body>trans-unit"`
+	Units          []xliff20Unit `xml:"unit"`
+}
+
+type xliff12Unit struct {
+	Id     string        `xml:"id,attr"`
+	Source xliffContent  `xml:"source"`
+	Target *xliffContent `xml:"target"`
+	Notes  []string      `xml:"note"`
+}
+
+type xliff20Unit struct {
+	Id    string   `xml:"id,attr"`
+	Notes []string `xml:"notes>note"`
+	Data  []struct {
+		Id    string `xml:"id,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"originalData>data"`
+	Segments []struct {
+		Source xliffContent  `xml:"source"`
+		Target *xliffContent `xml:"target"`
+	} `xml:"segment"`
+}
+
+type xliffContent struct {
+	Inner string `xml:",innerxml"`
+}
+
+func (x XliffDictionaryImporter) OpenMetadataFile(projectRoot string) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (x XliffDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
+	return dictionary.Metadata{}, errors.New("unsupported")
+}
+
+// OpenContentFile opens all XLIFF files in projectRoot as a single stream.
+func (x XliffDictionaryImporter) OpenContentFile(projectRoot string) (io.ReadCloser, error) {
+	return openDirectoryFiles(projectRoot, "*.xlf", "*.xliff")
+}
+
+func (x XliffDictionaryImporter) ImportContent(file io.Reader, metadata dictionary.Metadata) (dictionary.ContentRepresentation, error) {
+	importer := xliffImporter{
+		langSet: metadata.SupportedLanguageSet(),
+		result:  dictionary.FlattenedContent{},
+	}
+
+	decoder := xml.NewDecoder(file)
+	for {
+		document := xliffDocument{}
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &dictionary.FlattenedContent{}, errors.Wrap(err, "failed to decode XML")
+		}
+		if err := importer.importDocument(document); err != nil {
+			return &dictionary.FlattenedContent{}, err
+		}
+	}
+	return &importer.result, nil
+}
+
+type xliffImporter struct {
+	langSet map[string]struct{}
+	result  dictionary.FlattenedContent
+}
+
+func (x *xliffImporter) importDocument(document xliffDocument) error {
+	switch document.Version {
+	case "1.2":
+		for _, file := range document.Files {
+			if err := x.checkLanguages(file.SourceLanguage, file.TargetLanguage); err != nil {
+				return err
+			}
+			for _, unit := range file.TransUnits {
+				if err := x.import12Unit(unit, file.SourceLanguage, file.TargetLanguage); err != nil {
+					return errors.Wrapf(err, "invalid unit '%s'", unit.Id)
+				}
+			}
+		}
+	case "2.0", "2.1":
+		if err := x.checkLanguages(document.SrcLang, document.TrgLang); err != nil {
+			return err
+		}
+		for _, file := range document.Files {
+			for _, unit := range file.Units {
+				if err := x.import20Unit(unit, document.SrcLang, document.TrgLang); err != nil {
+					return errors.Wrapf(err, "invalid unit '%s'", unit.Id)
+				}
+			}
+		}
+	default:
+		return errors.Errorf("unsupported XLIFF version '%s'", document.Version)
+	}
+	return nil
+}
+
+func (x *xliffImporter) checkLanguages(languages ...string) error {
+	for _, lang := range languages {
+		if _, ok := x.langSet[lang]; !ok {
+			return errors.Errorf("unsupported language '%s'", lang)
+		}
+	}
+	return nil
+}
+
+func (x *xliffImporter) import12Unit(unit xliff12Unit, sourceLanguage, targetLanguage string) error {
+	placeholderFn := func(element xml.StartElement, inner string) (string, error) {
+		return inner, nil
+	}
+	source, err := unit.Source.text(placeholderFn)
+	if err != nil {
+		return err
+	}
+	target := ""
+	if unit.Target != nil {
+		if target, err = unit.Target.text(placeholderFn); err != nil {
+			return err
+		}
+	}
+	return x.setEntry(unit.Id, unit.Notes, sourceLanguage, source, targetLanguage, target)
+}
+
+func (x *xliffImporter) import20Unit(unit xliff20Unit, sourceLanguage, targetLanguage string) error {
+	data := map[string]string{}
+	for _, item := range unit.Data {
+		data[item.Id] = item.Value
+	}
+	placeholderFn := func(element xml.StartElement, _ string) (string, error) {
+		for _, attr := range element.Attr {
+			if attr.Name.Local != "dataRef" {
+				continue
+			}
+			if value, ok := data[attr.Value]; ok {
+				return value, nil
+			}
+			return "", errors.Errorf("unknown data reference '%s'", attr.Value)
+		}
+		return "", errors.New("placeholder without a data reference")
+	}
+
+	sourceBuilder, targetBuilder := strings.Builder{}, strings.Builder{}
+	for _, segment := range unit.Segments {
+		source, err := segment.Source.text(placeholderFn)
+		if err != nil {
+			return err
+		}
+		sourceBuilder.WriteString(source)
+		if segment.Target != nil {
+			target, err := segment.Target.text(placeholderFn)
+			if err != nil {
+				return err
+			}
+			targetBuilder.WriteString(target)
+		}
+	}
+	return x.setEntry(unit.Id, unit.Notes, sourceLanguage, sourceBuilder.String(), targetLanguage, targetBuilder.String())
+}
+
+func (x *xliffImporter) setEntry(id string, notes []string, sourceLanguage, source, targetLanguage, target string) error {
+	key := dictionary.EntryKey(id)
+	entry, ok := x.result[key]
+	if !ok {
+		entry = dictionary.Entry{}
+		x.result[key] = entry
+	}
+	if len(notes) > 0 {
+		entry["context"] = strings.Join(notes, "\n")
+	}
+	if _, exists := entry[sourceLanguage]; !exists && source != "" {
+		entry[sourceLanguage] = source
+	}
+	if target == "" {
+		return nil
+	}
+	if _, exists := entry[targetLanguage]; exists {
+		return errors.Errorf("duplicate key '%s' (%s)", id, targetLanguage)
+	}
+	entry[targetLanguage] = target
+	return nil
+}
+
+// text converts inline content to a donggu text.
+// placeholderFn returns the template of a placeholder element, given the element and its text content.
+func (x xliffContent) text(placeholderFn func(xml.StartElement, string) (string, error)) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(x.Inner))
+	builder := strings.Builder{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "invalid inline content")
+		}
+		switch token := token.(type) {
+		case xml.CharData:
+			builder.WriteString(string(token))
+		case xml.StartElement:
+			if token.Name.Local != "ph" {
+				return "", errors.Errorf("unsupported inline element '%s'", token.Name.Local)
+			}
+			inner := struct {
+				Value string `xml:",chardata"`
+			}{}
+			if err := decoder.DecodeElement(&inner, &token); err != nil {
+				return "", errors.Wrap(err, "invalid placeholder")
+			}
+			template, err := placeholderFn(token, inner.Value)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(template)
+		}
+	}
+	return builder.String(), nil
+}
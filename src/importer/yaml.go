@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// YamlDictionaryImporter imports content and metadata written in YAML.
+//
+// Content may be either flat, keyed by entry keys like content.json, or nested by the parts of entry keys.
+// In both forms, strings are fields of the entry at their path and mappings are subtrees,
+// so an entry can also have children. Mappings named `$` or `_` hold the fields of their parent.
+type YamlDictionaryImporter struct{}
+
+func (y YamlDictionaryImporter) OpenMetadataFile(projectRoot string) (io.ReadCloser, error) {
+	return openFirstExisting(path.Join(projectRoot, "metadata.yaml"), path.Join(projectRoot, "metadata.yml"))
+}
+
+func (y YamlDictionaryImporter) OpenContentFile(projectRoot string) (io.ReadCloser, error) {
+	return openFirstExisting(path.Join(projectRoot, "content.yaml"), path.Join(projectRoot, "content.yml"))
+}
+
+func (y YamlDictionaryImporter) ImportContent(file io.Reader, _ dictionary.Metadata) (dictionary.ContentRepresentation, error) {
+	content, _, err := y.ImportContentWithPositions(file, "")
+	return content, err
+}
+
+// ImportContentWithPositions is same as ImportContent, but also returns the positions of entries in the file.
+// fileName is used as the file of the positions.
+func (y YamlDictionaryImporter) ImportContentWithPositions(file io.Reader, fileName string) (
+	dictionary.ContentRepresentation, dictionary.ContentPositions, error,
+) {
+	var document yaml.Node
+	if err := yaml.NewDecoder(file).Decode(&document); err != nil {
+		if err == io.EOF {
+			return &dictionary.FlattenedContent{}, dictionary.ContentPositions{}, nil
+		}
+		return &dictionary.FlattenedContent{}, nil, errors.Wrap(err, "failed to decode YAML")
+	}
+
+	assembler := newContentAssembler()
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		assembler.AddError(yamlNodePosition(fileName, root), "content should be a mapping")
+	} else {
+		yamlContentWalker{assembler: assembler, fileName: fileName}.walk("", yamlNodePosition(fileName, root), root)
+	}
+	return assembler.Result()
+}
+
+func (y YamlDictionaryImporter) ImportMetadata(file io.Reader) (dictionary.Metadata, error) {
+	var decoded interface{}
+	if err := yaml.NewDecoder(file).Decode(&decoded); err != nil {
+		return dictionary.Metadata{}, errors.Wrap(err, "failed to decode YAML")
+	}
+	return importMetadataValue(decoded)
+}
+
+type yamlContentWalker struct {
+	assembler *contentAssembler
+	fileName  string
+}
+
+func (y yamlContentWalker) walk(key dictionary.EntryKey, keyPosition dictionary.SourcePosition, node *yaml.Node) {
+	defined := map[string]dictionary.SourcePosition{}
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode, valueNode := node.Content[index], resolveYamlAlias(node.Content[index+1])
+		name := keyNode.Value
+		namePosition := yamlNodePosition(y.fileName, keyNode)
+
+		if previous, ok := defined[name]; ok {
+			y.assembler.AddError(namePosition, fmt.Sprintf("duplicate key '%s', first defined at %s", name, previous))
+			continue
+		}
+		defined[name] = namePosition
+
+		switch {
+		case valueNode.Kind == yaml.MappingNode:
+			if childKey := nestedChildKey(key, name); childKey != key {
+				y.walk(childKey, namePosition, valueNode)
+			} else {
+				y.walk(key, keyPosition, valueNode)
+			}
+		case valueNode.Kind == yaml.ScalarNode && valueNode.Tag != "!!null":
+			y.assembler.AddField(key, keyPosition, name, valueNode.Value, namePosition, yamlNodePosition(y.fileName, valueNode))
+		default:
+			y.assembler.AddError(yamlNodePosition(y.fileName, valueNode), fmt.Sprintf("value of '%s' should be a string or a mapping", name))
+		}
+	}
+}
+
+func resolveYamlAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func yamlNodePosition(fileName string, node *yaml.Node) dictionary.SourcePosition {
+	return dictionary.SourcePosition{File: fileName, Line: node.Line, Column: node.Column}
+}
+
+// openFirstExisting opens the first file which exists among paths.
+func openFirstExisting(paths ...string) (io.ReadCloser, error) {
+	for _, filePath := range paths[:len(paths)-1] {
+		file, err := os.OpenFile(filePath, os.O_RDONLY, 0)
+		if !os.IsNotExist(err) {
+			return file, err
+		}
+	}
+	return os.OpenFile(paths[len(paths)-1], os.O_RDONLY, 0)
+}
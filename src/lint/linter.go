@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"github.com/hashicorp/go-multierror"
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// Rule checks an entry for issues which are not structural errors,
+// such as texts which differ between languages in ways they should not.
+type Rule interface {
+	// Name is the name of the rule, used as the key of the rule in the metadata.
+	Name() string
+	DefaultSeverity() dictionary.Severity
+	// Configure returns the rule with the rule specific options in the metadata applied.
+	Configure(options map[string]interface{}) (Rule, error)
+	Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem
+}
+
+// CheckContext holds values shared by all checks of a lint run.
+type CheckContext struct {
+	Metadata *dictionary.Metadata
+	// SourceLanguage is the language other languages are compared against.
+	SourceLanguage string
+}
+
+// Problem is an issue found by a rule in an entry.
+type Problem struct {
+	// Language is the language of the text with the issue. It is empty if the issue is in the whole entry.
+	Language string
+	Message  string
+}
+
+type configuredRule struct {
+	rule     Rule
+	severity dictionary.Severity
+}
+
+type Linter struct {
+	rules   []configuredRule
+	context CheckContext
+}
+
+// NewLinter configures rules with the lint configuration of the metadata.
+// Rules which are turned off are not run.
+func NewLinter(metadata dictionary.Metadata, rules []Rule) (*Linter, error) {
+	linter := &Linter{
+		context: CheckContext{Metadata: &metadata, SourceLanguage: metadata.LintSourceLanguage()},
+	}
+	var err *multierror.Error
+	known := map[string]struct{}{}
+	for _, rule := range rules {
+		known[rule.Name()] = struct{}{}
+		config := metadata.Lint.Rules[rule.Name()]
+		severity := rule.DefaultSeverity()
+		if config.Severity != "" {
+			severity = config.Severity
+		}
+		if severity == dictionary.SeverityOff {
+			continue
+		}
+		configured, configErr := rule.Configure(config.Options)
+		if configErr != nil {
+			err = multierror.Append(err, errors.Wrapf(configErr, "invalid options for rule '%s'", rule.Name()))
+			continue
+		}
+		linter.rules = append(linter.rules, configuredRule{rule: configured, severity: severity})
+	}
+	for name := range metadata.Lint.Rules {
+		if _, ok := known[name]; !ok {
+			err = multierror.Append(err, errors.Errorf("unknown rule '%s'", name))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return linter, nil
+}
+
+// Lint runs the rules over every entry of the content read from file.
+// The code of a diagnostic is the name of the rule which reported it.
+func (l *Linter) Lint(file string, content dictionary.ContentRepresentation) dictionary.Diagnostics {
+	diagnostics := dictionary.Diagnostics{}
+	for key, entry := range *content.ToFlattened() {
+		for _, rule := range l.rules {
+			for _, problem := range rule.rule.Check(l.context, key, entry) {
+				diagnostics = append(diagnostics, dictionary.Diagnostic{
+					Code:     dictionary.DiagnosticCode(rule.rule.Name()),
+					Severity: rule.severity,
+					Message:  problem.Message,
+					Key:      key,
+					Language: problem.Language,
+					Position: dictionary.SourcePosition{File: file},
+				})
+			}
+		}
+	}
+	diagnostics.Sort()
+	return diagnostics
+}
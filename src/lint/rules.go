@@ -0,0 +1,335 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// DefaultRules returns all rules provided by donggu.
+func DefaultRules() []Rule {
+	return []Rule{
+		PlaceholderMismatchRule{},
+		WhitespaceMismatchRule{},
+		PunctuationMismatchRule{},
+		UntranslatedRule{},
+		MissingContextRule{},
+		MaxLengthRule{},
+	}
+}
+
+// PlaceholderMismatchRule reports texts whose set of template keys differ from the source language.
+// Keys listed in the omitted templates of the entry are not reported.
+type PlaceholderMismatchRule struct{}
+
+func (p PlaceholderMismatchRule) Name() string { return "placeholder-mismatch" }
+
+func (p PlaceholderMismatchRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityError
+}
+
+func (p PlaceholderMismatchRule) Configure(options map[string]interface{}) (Rule, error) {
+	return p, noOptions(options)
+}
+
+func (p PlaceholderMismatchRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	sourceKeys, err := entry.TemplateKeys(ctx.SourceLanguage)
+	if err != nil {
+		return nil
+	}
+	omitted, _ := entry.OmittedTemplateKeys()
+	problems := []Problem{}
+	for _, lang := range comparedLanguages(ctx, entry) {
+		keys, err := entry.TemplateKeys(lang)
+		if err != nil {
+			continue
+		}
+		missing, extra := []string{}, []string{}
+		for templateKey := range sourceKeys {
+			_, isOmitted := omitted[lang][templateKey]
+			if _, ok := keys[templateKey]; !ok && !isOmitted {
+				missing = append(missing, templateKey)
+			}
+		}
+		for templateKey := range keys {
+			_, isOmitted := omitted[ctx.SourceLanguage][templateKey]
+			if _, ok := sourceKeys[templateKey]; !ok && !isOmitted {
+				extra = append(extra, templateKey)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			problems = append(problems, Problem{lang, fmt.Sprintf(
+				"missing placeholders %s of '%s'", strings.Join(missing, ", "), ctx.SourceLanguage,
+			)})
+		}
+		if len(extra) > 0 {
+			sort.Strings(extra)
+			problems = append(problems, Problem{lang, fmt.Sprintf(
+				"placeholders %s do not exist in '%s'", strings.Join(extra, ", "), ctx.SourceLanguage,
+			)})
+		}
+	}
+	return problems
+}
+
+// WhitespaceMismatchRule reports texts whose leading or trailing whitespace differ from the source language.
+type WhitespaceMismatchRule struct{}
+
+func (w WhitespaceMismatchRule) Name() string { return "whitespace-mismatch" }
+
+func (w WhitespaceMismatchRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityWarning
+}
+
+func (w WhitespaceMismatchRule) Configure(options map[string]interface{}) (Rule, error) {
+	return w, noOptions(options)
+}
+
+func (w WhitespaceMismatchRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	source := entry[ctx.SourceLanguage]
+	sourceLeading, sourceTrailing := surroundingSpace(source)
+	problems := []Problem{}
+	for _, lang := range comparedLanguages(ctx, entry) {
+		leading, trailing := surroundingSpace(entry[lang])
+		if leading != sourceLeading {
+			problems = append(problems, Problem{lang, fmt.Sprintf(
+				"leading whitespace %q differs from %q of '%s'", leading, sourceLeading, ctx.SourceLanguage,
+			)})
+		}
+		if trailing != sourceTrailing {
+			problems = append(problems, Problem{lang, fmt.Sprintf(
+				"trailing whitespace %q differs from %q of '%s'", trailing, sourceTrailing, ctx.SourceLanguage,
+			)})
+		}
+	}
+	return problems
+}
+
+func surroundingSpace(text string) (leading, trailing string) {
+	trimmedLeft := strings.TrimLeftFunc(text, unicode.IsSpace)
+	if trimmedLeft == "" {
+		return text, ""
+	}
+	trimmed := strings.TrimRightFunc(trimmedLeft, unicode.IsSpace)
+	return text[:len(text)-len(trimmedLeft)], trimmedLeft[len(trimmed):]
+}
+
+// endPunctuations maps punctuations which can end a text to their kind,
+// so that full width or localized forms of a punctuation are not reported.
+var endPunctuations = map[rune]rune{
+	'.': '.', '。': '.', '｡': '.', '।': '.', '…': '…',
+	'!': '!', '！': '!', '¡': '!',
+	'?': '?', '？': '?', '؟': '?', '¿': '?',
+	':': ':', '：': ':',
+	';': ';', '；': ';', '؛': ';',
+	',': ',', '，': ',', '、': ',', '،': ',',
+}
+
+// PunctuationMismatchRule reports texts which end with a different punctuation from the source language.
+type PunctuationMismatchRule struct{}
+
+func (p PunctuationMismatchRule) Name() string { return "punctuation-mismatch" }
+
+func (p PunctuationMismatchRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityWarning
+}
+
+func (p PunctuationMismatchRule) Configure(options map[string]interface{}) (Rule, error) {
+	return p, noOptions(options)
+}
+
+func (p PunctuationMismatchRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	sourceEnd, sourceKind := endPunctuation(entry[ctx.SourceLanguage])
+	problems := []Problem{}
+	for _, lang := range comparedLanguages(ctx, entry) {
+		end, kind := endPunctuation(entry[lang])
+		if kind == sourceKind {
+			continue
+		}
+		var message string
+		switch {
+		case sourceKind == 0:
+			message = fmt.Sprintf("ends with '%c', but '%s' does not end with a punctuation", end, ctx.SourceLanguage)
+		case kind == 0:
+			message = fmt.Sprintf("does not end with a punctuation, but '%s' ends with '%c'", ctx.SourceLanguage, sourceEnd)
+		default:
+			message = fmt.Sprintf("ends with '%c', but '%s' ends with '%c'", end, ctx.SourceLanguage, sourceEnd)
+		}
+		problems = append(problems, Problem{lang, message})
+	}
+	return problems
+}
+
+// endPunctuation returns the last character of text ignoring trailing whitespace,
+// and its kind if it is a punctuation. The kind is 0 if it is not a punctuation.
+func endPunctuation(text string) (rune, rune) {
+	last, _ := utf8.DecodeLastRuneInString(strings.TrimRightFunc(text, unicode.IsSpace))
+	return last, endPunctuations[last]
+}
+
+// UntranslatedRule reports texts which are identical to the source language.
+// Texts without any letters, such as numbers or symbols, are not reported.
+type UntranslatedRule struct{}
+
+func (u UntranslatedRule) Name() string { return "untranslated" }
+
+func (u UntranslatedRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityWarning
+}
+
+func (u UntranslatedRule) Configure(options map[string]interface{}) (Rule, error) {
+	return u, noOptions(options)
+}
+
+func (u UntranslatedRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	source := entry[ctx.SourceLanguage]
+	if strings.IndexFunc(dictionary.TemplateStrippedText(source), unicode.IsLetter) < 0 {
+		return nil
+	}
+	problems := []Problem{}
+	for _, lang := range comparedLanguages(ctx, entry) {
+		if entry[lang] == source {
+			problems = append(problems, Problem{lang, fmt.Sprintf("text is identical to '%s'", ctx.SourceLanguage)})
+		}
+	}
+	return problems
+}
+
+// MissingContextRule reports entries with templates but without context,
+// as translators cannot know what the templates are replaced with.
+type MissingContextRule struct{}
+
+func (m MissingContextRule) Name() string { return "missing-context" }
+
+func (m MissingContextRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityWarning
+}
+
+func (m MissingContextRule) Configure(options map[string]interface{}) (Rule, error) {
+	return m, noOptions(options)
+}
+
+func (m MissingContextRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	if strings.TrimSpace(entry["context"]) != "" {
+		return nil
+	}
+	for lang := range entry {
+		if dictionary.IsEntryMetaField(lang) {
+			continue
+		}
+		if keys, err := entry.TemplateKeys(lang); err == nil && len(keys) > 0 {
+			return []Problem{{Message: "entry has placeholders but no context"}}
+		}
+	}
+	return nil
+}
+
+// MaxLengthRule reports texts longer than a limit.
+// The length is the number of characters, excluding templates.
+//
+// Options:
+//   - max: the limit of all languages.
+//   - languages: the limits of each language, overriding max.
+type MaxLengthRule struct {
+	max       int
+	languages map[string]int
+}
+
+func (m MaxLengthRule) Name() string { return "max-length" }
+
+func (m MaxLengthRule) DefaultSeverity() dictionary.Severity {
+	return dictionary.SeverityError
+}
+
+func (m MaxLengthRule) Configure(options map[string]interface{}) (Rule, error) {
+	configured := MaxLengthRule{languages: map[string]int{}}
+	for key, value := range options {
+		switch key {
+		case "max":
+			limit, err := positiveIntOption(value)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid option 'max'")
+			}
+			configured.max = limit
+		case "languages":
+			languages, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("option 'languages' should be an object")
+			}
+			for lang, langValue := range languages {
+				limit, err := positiveIntOption(langValue)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid limit for language '%s'", lang)
+				}
+				configured.languages[lang] = limit
+			}
+		default:
+			return nil, errors.Errorf("unknown option '%s'", key)
+		}
+	}
+	return configured, nil
+}
+
+func (m MaxLengthRule) Check(ctx CheckContext, key dictionary.EntryKey, entry dictionary.Entry) []Problem {
+	problems := []Problem{}
+	for _, lang := range sortedLanguages(entry) {
+		limit, ok := m.languages[lang]
+		if !ok {
+			limit = m.max
+		}
+		if limit == 0 {
+			continue
+		}
+		if length := utf8.RuneCountInString(dictionary.TemplateStrippedText(entry[lang])); length > limit {
+			problems = append(problems, Problem{lang, fmt.Sprintf("text is %d characters long, exceeding the limit of %d", length, limit)})
+		}
+	}
+	return problems
+}
+
+func noOptions(options map[string]interface{}) error {
+	for key := range options {
+		return errors.Errorf("unknown option '%s'", key)
+	}
+	return nil
+}
+
+func positiveIntOption(value interface{}) (int, error) {
+	number, ok := value.(float64)
+	if !ok || number != float64(int(number)) || number <= 0 {
+		return 0, errors.Errorf("'%v' is not a positive integer", value)
+	}
+	return int(number), nil
+}
+
+// comparedLanguages returns the languages of an entry other than the source language, in order.
+// It is empty if the entry does not have the source language.
+func comparedLanguages(ctx CheckContext, entry dictionary.Entry) []string {
+	if _, ok := entry[ctx.SourceLanguage]; !ok {
+		return nil
+	}
+	languages := []string{}
+	for _, lang := range sortedLanguages(entry) {
+		if lang != ctx.SourceLanguage {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
+
+func sortedLanguages(entry dictionary.Entry) []string {
+	languages := make([]string, 0, len(entry))
+	for lang := range entry {
+		if !dictionary.IsEntryMetaField(lang) {
+			languages = append(languages, lang)
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,132 @@
+// Package pseudo synthesises pseudo-localized languages, to find truncated and hardcoded texts
+// before translations are available.
+package pseudo
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+// templateRune replaces templates while texts are pseudo-localized, so that templates are left untouched.
+const templateRune = '\uE000'
+
+var templateParenRegex = regexp.MustCompile(dictionary.TemplateParenPattern)
+
+var accents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î',
+	'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ',
+	's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î',
+	'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ',
+	'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// Options configures how texts are pseudo-localized.
+type Options struct {
+	// Accents replaces ASCII letters with accented letters.
+	Accents bool
+	// Expansion is the ratio of the length added to texts, such as 0.4 for texts 40% longer.
+	// Templates are not counted.
+	Expansion float64
+	// Brackets wraps texts with `[` and `]`, so that truncated texts are noticed.
+	Brackets bool
+}
+
+func DefaultOptions() Options {
+	return Options{Accents: true, Expansion: 0.4, Brackets: true}
+}
+
+// Localize returns the pseudo-localized text of a language of an entry. Templates are left untouched.
+func Localize(entry dictionary.Entry, lang string, options Options) (string, error) {
+	replaced, err := entry.ReplacedTemplateValue(lang, func(string, dictionary.TemplateKeyFormat) (string, error) {
+		return string(templateRune), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	builder := strings.Builder{}
+	if options.Brackets {
+		builder.WriteRune('[')
+	}
+	length := 0
+	for _, r := range replaced {
+		if r != templateRune {
+			length++
+			if accented, ok := accents[r]; ok && options.Accents {
+				r = accented
+			}
+		}
+		builder.WriteRune(r)
+	}
+	if padding := int(math.Ceil(float64(length) * options.Expansion)); padding > 0 {
+		builder.WriteString(strings.Repeat("~", padding))
+	}
+	if options.Brackets {
+		builder.WriteRune(']')
+	}
+
+	templates := templateParenRegex.FindAllString(entry[lang], -1)
+	localized := builder.String()
+	for _, template := range templates {
+		localized = strings.Replace(localized, string(templateRune), template, 1)
+	}
+	return localized, nil
+}
+
+// AddLanguage returns the content and metadata with a pseudo-localized language of the source language,
+// which is a supported language using the plural rules of the source language.
+func AddLanguage(
+	content dictionary.ContentRepresentation,
+	metadata dictionary.Metadata,
+	lang, source string,
+	options Options,
+) (dictionary.ContentRepresentation, dictionary.Metadata, error) {
+	supportedLangSet := metadata.SupportedLanguageSet()
+	if _, ok := supportedLangSet[lang]; ok {
+		return nil, metadata, errors.Errorf("language '%s' is already supported", lang)
+	}
+	if _, ok := supportedLangSet[source]; !ok {
+		return nil, metadata, errors.Errorf("source language '%s' is not supported", source)
+	}
+	if !dictionary.IsValidLanguageKey(lang) {
+		return nil, metadata, errors.Errorf("invalid language '%s'", lang)
+	}
+
+	localized := dictionary.FlattenedContent{}
+	for key, entry := range *content.ToFlattened() {
+		copied := make(dictionary.Entry, len(entry)+1)
+		for field, text := range entry {
+			copied[field] = text
+		}
+		if _, ok := entry[source]; ok {
+			text, err := Localize(entry, source, options)
+			if err != nil {
+				return nil, metadata, errors.Wrapf(err, "failed to pseudo-localize '%s'", key)
+			}
+			copied[lang] = text
+		}
+		localized[key] = copied
+	}
+
+	metadata.SupportedLanguages = append(append([]string{}, metadata.SupportedLanguages...), lang)
+	if _, ok := metadata.CldrPlurals[source]; ok {
+		cldrPlurals := map[string]struct{}{lang: {}}
+		for cldrLang := range metadata.CldrPlurals {
+			cldrPlurals[cldrLang] = struct{}{}
+		}
+		metadata.CldrPlurals = cldrPlurals
+	}
+	if definitions, ok := metadata.Plurals[source]; ok {
+		plurals := map[string][]dictionary.PluralDefinition{lang: definitions}
+		for pluralLang, langDefinitions := range metadata.Plurals {
+			plurals[pluralLang] = langDefinitions
+		}
+		metadata.Plurals = plurals
+	}
+	return &localized, metadata, nil
+}
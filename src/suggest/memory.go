@@ -0,0 +1,176 @@
+// Package suggest finds translations of similar texts in a project, to be reused for untranslated texts.
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// Suggestion is a translation of an entry proposed from the translation of a similar entry.
+type Suggestion struct {
+	Key      dictionary.EntryKey
+	Language string
+	// Source is the text of the source language of the entry.
+	Source string
+	// Current is the text of the language, which is empty if Missing is true.
+	Current string
+	Missing bool
+
+	// MatchKey and MatchSource are the key and source text of the similar entry.
+	MatchKey    dictionary.EntryKey
+	MatchSource string
+	// Text is the translation of the similar entry, with the keys of templates renamed to the keys of the entry.
+	Text string
+	// Score is the similarity between Source and MatchSource, between 0 and 1.
+	Score float64
+}
+
+// Options configures which suggestions are made.
+type Options struct {
+	// MinScore is the lowest similarity of suggestions.
+	MinScore float64
+	// Limit is the number of suggestions for each text. Zero is unlimited.
+	Limit int
+}
+
+type memoryUnit struct {
+	key    dictionary.EntryKey
+	entry  dictionary.Entry
+	tokens []rune
+}
+
+// TranslationMemory holds the entries of a content with texts of the source language,
+// and looks up the translations of entries similar to a text.
+type TranslationMemory struct {
+	sourceLanguage string
+	units          []memoryUnit
+}
+
+func NewTranslationMemory(content dictionary.ContentRepresentation, sourceLanguage string) *TranslationMemory {
+	memory := &TranslationMemory{sourceLanguage: sourceLanguage}
+	for key, entry := range *content.ToFlattened() {
+		source, ok := entry[sourceLanguage]
+		if !ok || strings.TrimSpace(source) == "" {
+			continue
+		}
+		memory.units = append(memory.units, memoryUnit{key: key, entry: entry, tokens: similarityTokens(source)})
+	}
+	sort.Slice(memory.units, func(i, j int) bool { return memory.units[i].key < memory.units[j].key })
+	return memory
+}
+
+// Suggest returns suggestions for every text of languages which is missing or equal to the text of the source language,
+// sorted by key, language and descending score.
+func (t *TranslationMemory) Suggest(languages []string, options Options) []Suggestion {
+	suggestions := []Suggestion{}
+	for _, unit := range t.units {
+		source := unit.entry[t.sourceLanguage]
+		if strings.IndexFunc(dictionary.TemplateStrippedText(source), unicode.IsLetter) < 0 {
+			continue
+		}
+		for _, lang := range languages {
+			current, exists := unit.entry[lang]
+			if lang == t.sourceLanguage || (exists && current != source) {
+				continue
+			}
+			found := t.lookup(unit, lang, options)
+			for index := range found {
+				found[index].Current = current
+				found[index].Missing = !exists
+			}
+			suggestions = append(suggestions, found...)
+		}
+	}
+	return suggestions
+}
+
+// lookup returns the translations to lang of the entries similar to unit, in descending score.
+// Entries without the translation, or with templates which cannot be matched to the templates of unit, are skipped.
+func (t *TranslationMemory) lookup(target memoryUnit, lang string, options Options) []Suggestion {
+	source := target.entry[t.sourceLanguage]
+	sourceKeys := templateKeys(source)
+
+	found := []Suggestion{}
+	for _, unit := range t.units {
+		if unit.key == target.key {
+			continue
+		}
+		translation, ok := unit.entry[lang]
+		matchSource := unit.entry[t.sourceLanguage]
+		if !ok || translation == matchSource {
+			continue
+		}
+		if maxSimilarity(len(target.tokens), len(unit.tokens)) < options.MinScore {
+			continue
+		}
+		score := similarity(target.tokens, unit.tokens)
+		if score < options.MinScore {
+			continue
+		}
+		matchKeys := templateKeys(matchSource)
+		if len(matchKeys) != len(sourceKeys) {
+			continue
+		}
+		names := map[string]string{}
+		for index, key := range matchKeys {
+			names[key] = sourceKeys[index]
+		}
+		if !templateKindsCompatible(target.entry, t.sourceLanguage, unit.entry, lang, names) {
+			continue
+		}
+
+		found = append(found, Suggestion{
+			Key:         target.key,
+			Language:    lang,
+			Source:      source,
+			MatchKey:    unit.key,
+			MatchSource: matchSource,
+			Text:        renameTemplateKeys(translation, names),
+			Score:       score,
+		})
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Score > found[j].Score })
+	found = uniqueTexts(found)
+	if options.Limit > 0 && len(found) > options.Limit {
+		found = found[:options.Limit]
+	}
+	return found
+}
+
+// templateKindsCompatible reports whether the templates of the translation to lang of match can be used in the entry
+// after their keys are renamed by names, that is, the kind of each template is compatible with the kind of
+// the renamed template in the source language of the entry.
+func templateKindsCompatible(entry dictionary.Entry, sourceLanguage string, match dictionary.Entry, lang string, names map[string]string) bool {
+	formats, err := entry.TemplateKeys(sourceLanguage)
+	if err != nil {
+		return false
+	}
+	matchFormats, err := match.TemplateKeys(lang)
+	if err != nil {
+		return false
+	}
+	for key, matchFormat := range matchFormats {
+		format, ok := formats[names[key]]
+		if !ok || !matchFormat.Compatible(format) {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueTexts removes suggestions with the same text as a preceding suggestion.
+func uniqueTexts(suggestions []Suggestion) []Suggestion {
+	unique := suggestions[:0]
+	seen := map[string]struct{}{}
+	for _, suggestion := range suggestions {
+		if _, ok := seen[suggestion.Text]; !ok {
+			seen[suggestion.Text] = struct{}{}
+			unique = append(unique, suggestion)
+		}
+	}
+	return unique
+}
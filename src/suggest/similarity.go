@@ -0,0 +1,99 @@
+package suggest
+
+import (
+	"regexp"
+
+	"github.com/maasasia/donggu/dictionary"
+)
+
+// placeholderRune replaces templates in texts compared for similarity,
+// so that a template is a single token regardless of its key and format.
+const placeholderRune = '\uFFFC'
+
+var templateParenRegex = regexp.MustCompile(dictionary.TemplateParenPattern)
+var templateOptionRegex = regexp.MustCompile(dictionary.TemplateOptionPattern)
+
+// similarityTokens returns the characters of a text compared for similarity, with templates replaced by placeholderRune.
+func similarityTokens(text string) []rune {
+	return []rune(templateParenRegex.ReplaceAllString(text, string(placeholderRune)))
+}
+
+// similarity returns the normalised edit distance between two texts as a score between 0 and 1,
+// where 1 is identical.
+func similarity(a, b []rune) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(a, b))/float64(longest)
+}
+
+// maxSimilarity returns the highest score two texts of the lengths can have,
+// to skip computing the edit distance of texts which cannot be similar enough.
+func maxSimilarity(aLength, bLength int) float64 {
+	longest, shortest := aLength, bLength
+	if shortest > longest {
+		longest, shortest = shortest, longest
+	}
+	if longest == 0 {
+		return 1
+	}
+	return float64(shortest) / float64(longest)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, value := range rest {
+		if value < first {
+			first = value
+		}
+	}
+	return first
+}
+
+// templateKeys returns the keys of the templates of a text, in the order they first appear.
+func templateKeys(text string) []string {
+	keys := []string{}
+	seen := map[string]struct{}{}
+	for _, match := range templateOptionRegex.FindAllStringSubmatch(text, -1) {
+		if _, ok := seen[match[1]]; !ok {
+			seen[match[1]] = struct{}{}
+			keys = append(keys, match[1])
+		}
+	}
+	return keys
+}
+
+// renameTemplateKeys renames the keys of the templates of a text, keeping their formats.
+func renameTemplateKeys(text string, names map[string]string) string {
+	return templateOptionRegex.ReplaceAllStringFunc(text, func(template string) string {
+		key := templateOptionRegex.FindStringSubmatch(template)[1]
+		if name, ok := names[key]; ok {
+			return "#{" + name + template[len("#{")+len(key):]
+		}
+		return template
+	})
+}
@@ -0,0 +1,80 @@
+package translate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HttpTranslator translates texts with an HTTP endpoint.
+//
+// Each text is sent as a POST request with a JSON body, such as
+// `{"source": "en", "target": "ko", "text": "Hello <x id=\"0\"/>"}`,
+// and the endpoint should respond with the status 200 and a JSON body, such as `{"text": "안녕 <x id=\"0\"/>"}`.
+// If Token is set, it is sent as a bearer token in the Authorization header.
+type HttpTranslator struct {
+	Endpoint string
+	Token    string
+	Client   *http.Client
+}
+
+type httpTranslateRequest struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Text   string `json:"text"`
+}
+
+type httpTranslateResponse struct {
+	Text *string `json:"text"`
+}
+
+// NewHttpTranslator returns a HttpTranslator with a client timing out after 30 seconds.
+func NewHttpTranslator(endpoint, token string) HttpTranslator {
+	return HttpTranslator{Endpoint: endpoint, Token: token, Client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (h HttpTranslator) Translate(source, target, text string) (string, error) {
+	body, err := json.Marshal(httpTranslateRequest{Source: source, Target: target, Text: text})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode request")
+	}
+	request, err := http.NewRequest(http.MethodPost, h.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid endpoint")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if h.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+h.Token)
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", errors.Wrap(err, "request failed")
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read response")
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("endpoint responded with %s: %s", response.Status, strings.TrimSpace(string(responseBody)))
+	}
+	var decoded httpTranslateResponse
+	if err := json.Unmarshal(responseBody, &decoded); err != nil {
+		return "", errors.Wrap(err, "failed to decode response")
+	}
+	if decoded.Text == nil {
+		return "", errors.New("response has no text")
+	}
+	return *decoded.Text, nil
+}
@@ -0,0 +1,9 @@
+package translate
+
+// PseudoTranslator is a deterministic translator for testing, which prefixes texts with the target language,
+// such as `[ko] Hello`.
+type PseudoTranslator struct{}
+
+func (p PseudoTranslator) Translate(_, target, text string) (string, error) {
+	return "[" + target + "] " + text, nil
+}
@@ -0,0 +1,160 @@
+// Package translate translates texts of entries with machine translation providers.
+package translate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/maasasia/donggu/dictionary"
+	"github.com/pkg/errors"
+)
+
+var templateParenRegex = regexp.MustCompile(dictionary.TemplateParenPattern)
+var templateOptionRegex = regexp.MustCompile(dictionary.TemplateOptionPattern)
+
+// Translator translates texts with a machine translation provider.
+type Translator interface {
+	// Translate translates text from the source language to the target language.
+	// Templates of the text are replaced with placeholder tokens such as `<x id="0"/>`,
+	// which should be kept as they are in the translation.
+	Translate(source, target, text string) (string, error)
+}
+
+// TranslateText translates a text with templates. Templates are protected from the translator
+// as placeholder tokens, and restored in the translation.
+//
+// The choices of plural templates, and of bool templates with their own values, are translated as separate texts.
+// Plural choices are rebuilt for the plural categories of the target language, taking the choice of the same
+// category in the source language, or the choice of 'other' if the source language does not have the category.
+func TranslateText(translator Translator, metadata dictionary.Metadata, source, target, text string) (string, error) {
+	t := textTranslation{translator: translator, metadata: metadata, source: source, target: target, choices: map[string]string{}}
+
+	protected, templates := protectTemplates(text)
+	translatedTemplates := make([]string, 0, len(templates))
+	for _, template := range templates {
+		translatedTemplate, err := t.translateTemplate(template)
+		if err != nil {
+			return "", err
+		}
+		translatedTemplates = append(translatedTemplates, translatedTemplate)
+	}
+
+	translated, err := translator.Translate(source, target, protected)
+	if err != nil {
+		return "", err
+	}
+	return restoreTemplates(translated, templates, translatedTemplates)
+}
+
+// textTranslation translates the templates of a text, translating each distinct choice once.
+type textTranslation struct {
+	translator     Translator
+	metadata       dictionary.Metadata
+	source, target string
+	choices        map[string]string
+}
+
+// translateTemplate returns the template with its choices translated. Templates without translatable text are returned as is.
+func (t textTranslation) translateTemplate(template string) (string, error) {
+	match := templateOptionRegex.FindStringSubmatch(template)
+	if match == nil {
+		return "", errors.Errorf("invalid template '%s'", template)
+	}
+	format, err := dictionary.ParseTemplateKeyFormat(match[2], match[3])
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid template '%s'", template)
+	}
+	if !format.Translatable() {
+		return template, nil
+	}
+
+	switch option := format.Option.(type) {
+	case dictionary.PluralTemplateFormatOption:
+		sourceChoices, err := option.ChoicesFor(t.metadata.PluralCategories(t.source))
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid template '%s'", template)
+		}
+		categories := t.metadata.PluralCategories(t.target)
+		choices := make([]string, 0, len(categories))
+		for _, category := range categories {
+			choice, ok := sourceChoices[category]
+			if !ok {
+				choice = sourceChoices[dictionary.OtherPluralCategory]
+			}
+			translated, err := t.translateChoice(template, choice)
+			if err != nil {
+				return "", err
+			}
+			choices = append(choices, string(category)+":"+translated)
+		}
+		return fmt.Sprintf("#{%s|%s|%s}", match[1], format.Kind, strings.Join(choices, ",")), nil
+	case dictionary.BoolTemplateFormatOption:
+		trueValue, err := t.translateChoice(template, option.TrueValue)
+		if err != nil {
+			return "", err
+		}
+		falseValue, err := t.translateChoice(template, option.FalseValue)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("#{%s|%s|%s,%s}", match[1], format.Kind, trueValue, falseValue), nil
+	default:
+		return template, nil
+	}
+}
+
+// translateChoice translates a choice of a template, keeping its leading and trailing spaces.
+// Blank choices are not translated.
+func (t textTranslation) translateChoice(template, choice string) (string, error) {
+	trimmed := strings.TrimSpace(choice)
+	if trimmed == "" {
+		return choice, nil
+	}
+	translated, ok := t.choices[trimmed]
+	if !ok {
+		var err error
+		if translated, err = t.translator.Translate(t.source, t.target, trimmed); err != nil {
+			return "", errors.Wrapf(err, "failed to translate choice '%s' of '%s'", trimmed, template)
+		}
+		translated = strings.TrimSpace(translated)
+		// Choices are separated by ',' and the template ends with '}', which cannot be escaped.
+		if strings.ContainsAny(translated, ",}") {
+			return "", errors.Errorf("translation '%s' of choice '%s' of '%s' cannot be a choice: it contains ',' or '}'", translated, trimmed, template)
+		}
+		t.choices[trimmed] = translated
+	}
+	leading := choice[:strings.Index(choice, trimmed)]
+	return leading + translated + choice[len(leading)+len(trimmed):], nil
+}
+
+func placeholderToken(index int) string {
+	return fmt.Sprintf(`<x id="%d"/>`, index)
+}
+
+// protectTemplates replaces the templates of text with placeholder tokens, and returns the replaced templates in order.
+func protectTemplates(text string) (string, []string) {
+	templates := []string{}
+	protected := templateParenRegex.ReplaceAllStringFunc(text, func(template string) string {
+		templates = append(templates, template)
+		return placeholderToken(len(templates) - 1)
+	})
+	return protected, templates
+}
+
+// restoreTemplates replaces the placeholder tokens of a translation with the translated templates.
+// Every token should appear exactly once.
+func restoreTemplates(translated string, templates, translatedTemplates []string) (string, error) {
+	for index, template := range templates {
+		token := placeholderToken(index)
+		switch strings.Count(translated, token) {
+		case 0:
+			return "", errors.Errorf("translation lost placeholder of '%s'", template)
+		case 1:
+			translated = strings.Replace(translated, token, translatedTemplates[index], 1)
+		default:
+			return "", errors.Errorf("translation repeated placeholder of '%s'", template)
+		}
+	}
+	return translated, nil
+}
@@ -46,3 +46,10 @@ func printBooleanValue(value bool, trueValue, falseValue string) string {
 		return falseValue
 	}
 }
+
+func selectPluralChoice(category string, choices map[string]string) string {
+	if choice, ok := choices[category]; ok {
+		return choice
+	}
+	return choices["other"]
+}
@@ -0,0 +1,37 @@
+package donggu.template
+
+import java.util.Locale
+
+/**
+ * Chooses the language of a text.
+ * `query` returns whether the text exists in a language.
+ */
+typealias ResolverFunc = (query: (language: String) -> Boolean) -> String
+
+class Donggu(private val resolver: ResolverFunc) {
+    val version: String
+        get() = VERSION
+
+    internal fun <T> resolve(key: String, formatters: Map<String, T>): T {
+        val chosenLanguage = resolver { formatters.containsKey(it) }
+        if (!isValidLanguage(chosenLanguage)) {
+            throw IllegalArgumentException("language '$chosenLanguage' provided by resolver is invalid")
+        }
+        return formatters[chosenLanguage]
+            ?: throw IllegalStateException("cannot resolve function '$key'")
+    }
+
+    companion object {
+        fun isValidLanguage(language: String): Boolean = LANGUAGES.containsKey(language)
+
+        fun isRequiredLanguage(language: String): Boolean = LANGUAGES[language] ?: false
+    }
+}
+
+internal fun formatNumber(format: String, value: Any): String = String.format(Locale.ROOT, format, value)
+
+internal fun printBooleanValue(value: Boolean, trueValue: String, falseValue: String): String =
+    if (value) trueValue else falseValue
+
+internal fun selectPluralChoice(category: String, choices: Map<String, String>): String =
+    choices[category] ?: choices["other"] ?: ""
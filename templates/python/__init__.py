@@ -0,0 +1,3 @@
+from .donggu import Donggu, ResolverFunc, is_required_language, is_valid_language
+
+__all__ = ["Donggu", "ResolverFunc", "is_required_language", "is_valid_language"]
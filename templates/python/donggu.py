@@ -0,0 +1,35 @@
+from typing import Callable, Dict, TypeVar
+
+from .generated.language import LANGUAGES, VERSION
+from .generated.nodes import DictionaryRoot
+
+# ResolverFunc chooses the language of a text.
+# The argument is a function returning whether the text exists in a language.
+ResolverFunc = Callable[[Callable[[str], bool]], str]
+
+T = TypeVar("T")
+
+
+def is_valid_language(language: str) -> bool:
+    return language in LANGUAGES
+
+
+def is_required_language(language: str) -> bool:
+    return LANGUAGES.get(language, False)
+
+
+class Donggu(DictionaryRoot):
+    def __init__(self, resolver: ResolverFunc) -> None:
+        self._resolver = resolver
+
+    @property
+    def version(self) -> str:
+        return VERSION
+
+    def _resolve(self, key: str, formatters: Dict[str, T]) -> T:
+        chosen_language = self._resolver(lambda language: language in formatters)
+        if not is_valid_language(chosen_language):
+            raise ValueError(f"language '{chosen_language}' provided by resolver is invalid")
+        if chosen_language not in formatters:
+            raise LookupError(f"cannot resolve function '{key}'")
+        return formatters[chosen_language]
@@ -0,0 +1,11 @@
+from typing import Dict
+
+
+def print_boolean_value(value: bool, true_value: str, false_value: str) -> str:
+    return true_value if value else false_value
+
+
+def select_plural_choice(category: str, choices: Dict[str, str]) -> str:
+    if category in choices:
+        return choices[category]
+    return choices.get("other", "")
@@ -0,0 +1,3 @@
+pub(crate) mod data;
+pub(crate) mod language;
+pub mod nodes;
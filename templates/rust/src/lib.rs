@@ -0,0 +1,103 @@
+mod generated;
+
+pub use generated::nodes::*;
+
+use generated::language::{LANGUAGES, VERSION};
+
+/// Chooses the language of a text.
+/// The argument returns whether the text exists in a language.
+pub type ResolverFunc = dyn Fn(&dyn Fn(&str) -> bool) -> String + Send + Sync;
+
+pub struct Donggu {
+    resolver: Box<ResolverFunc>,
+}
+
+impl Donggu {
+    pub fn new<F>(resolver: F) -> Self
+    where
+        F: Fn(&dyn Fn(&str) -> bool) -> String + Send + Sync + 'static,
+    {
+        Donggu {
+            resolver: Box::new(resolver),
+        }
+    }
+
+    pub fn version(&self) -> &'static str {
+        VERSION
+    }
+
+    pub fn is_valid_language(language: &str) -> bool {
+        LANGUAGES.iter().any(|(lang, _)| *lang == language)
+    }
+
+    pub fn is_required_language(language: &str) -> bool {
+        LANGUAGES
+            .iter()
+            .any(|(lang, required)| *lang == language && *required)
+    }
+
+    pub(crate) fn resolve<T: Copy>(&self, key: &str, formatters: &[(&str, T)]) -> T {
+        let chosen_language = (self.resolver)(&|language| {
+            formatters.iter().any(|(lang, _)| *lang == language)
+        });
+        if !Donggu::is_valid_language(&chosen_language) {
+            panic!("language '{}' provided by resolver is invalid", chosen_language);
+        }
+        match formatters.iter().find(|(lang, _)| *lang == chosen_language) {
+            Some((_, formatter)) => *formatter,
+            None => panic!("cannot resolve function '{}'", key),
+        }
+    }
+}
+
+#[allow(dead_code)]
+pub(crate) fn print_boolean_value(value: bool, true_value: &'static str, false_value: &'static str) -> &'static str {
+    if value {
+        true_value
+    } else {
+        false_value
+    }
+}
+
+#[allow(dead_code)]
+pub(crate) fn select_plural_choice(category: &str, choices: &[(&str, &'static str)]) -> &'static str {
+    let find = |name: &str| choices.iter().find(|(c, _)| *c == name).map(|(_, v)| *v);
+    find(category).or_else(|| find("other")).unwrap_or("")
+}
+
+/// Inserts separators to the integer digits of a formatted number, which is the first run of digits,
+/// and pads the result to `width`. Zeros are padded to the digits before they are grouped,
+/// as Python and Java do, such as `-000,042`.
+#[allow(dead_code)]
+pub(crate) fn format_grouped(formatted: String, width: usize, zero_pad: bool) -> String {
+    let start = formatted.find(|c: char| c.is_ascii_digit()).unwrap_or(formatted.len());
+    let end = formatted[start..]
+        .find(|c: char| !c.is_ascii_digit())
+        .map_or(formatted.len(), |index| start + index);
+    let (prefix, suffix) = (&formatted[..start], &formatted[end..]);
+    let mut digits = formatted[start..end].to_string();
+
+    let digits_width = width.saturating_sub(prefix.len() + suffix.len());
+    let mut grouped = group_digits(&digits);
+    while zero_pad && !digits.is_empty() && grouped.len() < digits_width {
+        digits.insert(0, '0');
+        grouped = group_digits(&digits);
+    }
+    let mut result = format!("{}{}{}", prefix, grouped, suffix);
+    while result.len() < width {
+        result.insert(0, ' ');
+    }
+    result
+}
+
+#[allow(dead_code)]
+fn group_digits(digits: &str) -> String {
+    let mut grouped = String::new();
+    for (index, character) in digits.chars().enumerate() {
+        if index > 0 && (digits.len() - index) % 3 == 0 {
+            grouped.push(',');
+        }
+        grouped.push(character);
+    }
+    grouped
+}
@@ -0,0 +1,12 @@
+// swift-tools-version:5.5
+import PackageDescription
+
+let package = Package(
+    name: "DongguTemplate",
+    products: [
+        .library(name: "DongguTemplate", targets: ["DongguTemplate"]),
+    ],
+    targets: [
+        .target(name: "DongguTemplate", path: "Sources"),
+    ]
+)
@@ -0,0 +1,81 @@
+import Foundation
+
+/// Chooses the language of a text.
+/// `query` returns whether the text exists in a language.
+public typealias ResolverFunc = (_ query: (String) -> Bool) -> String
+
+public struct Donggu {
+    private let resolver: ResolverFunc
+
+    public init(resolver: @escaping ResolverFunc) {
+        self.resolver = resolver
+    }
+
+    public var version: String {
+        dictionaryVersion
+    }
+
+    func resolve<T>(_ key: String, _ formatters: [String: T]) -> T {
+        let chosenLanguage = resolver { formatters[$0] != nil }
+        guard Donggu.isValidLanguage(chosenLanguage) else {
+            fatalError("language '\(chosenLanguage)' provided by resolver is invalid")
+        }
+        guard let formatter = formatters[chosenLanguage] else {
+            fatalError("cannot resolve function '\(key)'")
+        }
+        return formatter
+    }
+
+    public static func isValidLanguage(_ language: String) -> Bool {
+        languages[language] != nil
+    }
+
+    public static func isRequiredLanguage(_ language: String) -> Bool {
+        languages[language] ?? false
+    }
+}
+
+func formatNumber(_ format: String, _ value: CVarArg, grouping: Bool = false) -> String {
+    let formatted = String(format: format, locale: Locale(identifier: "en_US_POSIX"), value)
+    guard grouping else {
+        return formatted
+    }
+    // Insert separators to the integer digits, which is the first run of digits.
+    var result = ""
+    var digits = ""
+    var grouped = false
+    for character in formatted {
+        if !grouped && character.isASCII && character.isNumber {
+            digits.append(character)
+            continue
+        }
+        if !grouped && !digits.isEmpty {
+            result += groupDigits(digits)
+            grouped = true
+        }
+        result.append(character)
+    }
+    if !grouped {
+        result += groupDigits(digits)
+    }
+    return result
+}
+
+private func groupDigits(_ digits: String) -> String {
+    var result = ""
+    for (index, character) in digits.enumerated() {
+        if index > 0 && (digits.count - index) % 3 == 0 {
+            result.append(",")
+        }
+        result.append(character)
+    }
+    return result
+}
+
+func printBooleanValue(_ value: Bool, _ trueValue: String, _ falseValue: String) -> String {
+    value ? trueValue : falseValue
+}
+
+func selectPluralChoice(_ category: String, _ choices: [String: String]) -> String {
+    choices[category] ?? choices["other"] ?? ""
+}
@@ -27,7 +27,7 @@ export const Formatter = {
     bool: (v: boolean) => {
         return useWrapper(v ? 'yes' : 'no');
     },
-    plural: (v: number, lang: Language, values: string[]) => values[PLURALS[lang](v)],
+    plural: (v: number, lang: Language, values: Record<string, string>) => values[PLURALS[lang](v)] ?? values.other,
 }
 
 function useWrapper(text: string, Wrap?: React.ComponentType<{children: React.ReactNode}>) {
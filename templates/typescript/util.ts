@@ -16,7 +16,7 @@ export const Formatter = {
     float: (v: number, options: FloatFormatterOptions | null) => formatNumeric(v, options),
     // TODO: i18n
     bool: (v: boolean) => v ? 'yes' : 'no',
-    plural: (v: number, lang: Language, values: string[]) => values[PLURALS[lang](v)],
+    plural: (v: number, lang: Language, values: Record<string, string>) => values[PLURALS[lang](v)] ?? values.other,
 }
 
 function formatNumeric(value: number, options: FloatFormatterOptions | null): string {